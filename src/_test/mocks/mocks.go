@@ -5,13 +5,20 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 )
 
 var (
 	testGetGameDataResponse = []map[string]interface{}{
 		{
-			"artworks": []int{358989},
+			"artworks": []map[string]interface{}{
+				{
+					"id":    358989,
+					"width": 1920,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/ar7nlx.jpg",
+				},
+			},
 			"cover": map[string]interface{}{
 				"id":    136520,
 				"width": 1000,
@@ -21,21 +28,45 @@ var (
 			"franchise":          24,
 			"game_status":        0,
 			"game_type":          0,
-			"genres":             []int{8},
-			"id":                 1068,
-			"name":               "Super Mario Bros. 3",
+			"franchises": []map[string]interface{}{
+				{"id": 24, "name": "Mario"},
+			},
+			"genres": []map[string]interface{}{
+				{"id": 8, "name": "Platform"},
+			},
+			"involved_companies": []map[string]interface{}{
+				{"id": 4018, "company": map[string]interface{}{"id": 70, "name": "Nintendo"}, "developer": true, "publisher": true},
+			},
+			"id":   1068,
+			"name": "Super Mario Bros. 3",
 			"platforms": []map[string]interface{}{
 				{
 					"id":   6,
 					"name": "NES",
 				},
 			},
+			"screenshots": []map[string]interface{}{
+				{
+					"id":    9671,
+					"width": 889,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/sc7hl.jpg",
+				},
+			},
 			"storyline": "A storyline supplement from mocked IGDB data for test.",
 			"summary":   "A summary supplement from mocked IGDB data for test.",
-			"videos":    []int{35343, 20256},
+			"videos": []map[string]interface{}{
+				{"id": 35343, "name": "Trailer", "video_id": "mR6ZfUwPgqk"},
+				{"id": 20256, "name": "Gameplay video", "video_id": "VhBiaA7Ba3Q"},
+			},
 		},
 		{
-			"artworks": []int{358989},
+			"artworks": []map[string]interface{}{
+				{
+					"id":    358989,
+					"width": 1920,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/ar7nlx.jpg",
+				},
+			},
 			"cover": map[string]interface{}{
 				"id":    136520,
 				"width": 1000,
@@ -45,21 +76,45 @@ var (
 			"franchise":          24,
 			"game_status":        0,
 			"game_type":          0,
-			"genres":             []int{8},
-			"id":                 1069,
-			"name":               "Super Mario Bros. 4",
+			"franchises": []map[string]interface{}{
+				{"id": 24, "name": "Mario"},
+			},
+			"genres": []map[string]interface{}{
+				{"id": 8, "name": "Platform"},
+			},
+			"involved_companies": []map[string]interface{}{
+				{"id": 4018, "company": map[string]interface{}{"id": 70, "name": "Nintendo"}, "developer": true, "publisher": true},
+			},
+			"id":   1069,
+			"name": "Super Mario Bros. 4",
 			"platforms": []map[string]interface{}{
 				{
 					"id":   6,
 					"name": "NES",
 				},
 			},
+			"screenshots": []map[string]interface{}{
+				{
+					"id":    9671,
+					"width": 889,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/sc7hl.jpg",
+				},
+			},
 			"storyline": "A storyline supplement from mocked IGDB data for test.",
 			"summary":   "A summary supplement from mocked IGDB data for test.",
-			"videos":    []int{35343, 20256},
+			"videos": []map[string]interface{}{
+				{"id": 35343, "name": "Trailer", "video_id": "mR6ZfUwPgqk"},
+				{"id": 20256, "name": "Gameplay video", "video_id": "VhBiaA7Ba3Q"},
+			},
 		},
 		{
-			"artworks": []int{358989},
+			"artworks": []map[string]interface{}{
+				{
+					"id":    358989,
+					"width": 1920,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/ar7nlx.jpg",
+				},
+			},
 			"cover": map[string]interface{}{
 				"id":    136520,
 				"width": 1000,
@@ -69,21 +124,45 @@ var (
 			"franchise":          24,
 			"game_status":        0,
 			"game_type":          0,
-			"genres":             []int{8},
-			"id":                 1337,
-			"name":               "8 Eyes",
+			"franchises": []map[string]interface{}{
+				{"id": 24, "name": "Mario"},
+			},
+			"genres": []map[string]interface{}{
+				{"id": 8, "name": "Platform"},
+			},
+			"involved_companies": []map[string]interface{}{
+				{"id": 4018, "company": map[string]interface{}{"id": 70, "name": "Nintendo"}, "developer": true, "publisher": true},
+			},
+			"id":   1337,
+			"name": "8 Eyes",
 			"platforms": []map[string]interface{}{
 				{
 					"id":   6,
 					"name": "NES",
 				},
 			},
+			"screenshots": []map[string]interface{}{
+				{
+					"id":    9671,
+					"width": 889,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/sc7hl.jpg",
+				},
+			},
 			"storyline": "A storyline supplement from mocked IGDB data for test for 8 Eyes.",
 			"summary":   "A summary supplement from mocked IGDB data for test for 8 Eyes.",
-			"videos":    []int{35343, 20256},
+			"videos": []map[string]interface{}{
+				{"id": 35343, "name": "Trailer", "video_id": "mR6ZfUwPgqk"},
+				{"id": 20256, "name": "Gameplay video", "video_id": "VhBiaA7Ba3Q"},
+			},
 		},
 		{
-			"artworks": []int{358989},
+			"artworks": []map[string]interface{}{
+				{
+					"id":    358989,
+					"width": 1920,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/ar7nlx.jpg",
+				},
+			},
 			"cover": map[string]interface{}{
 				"id":    136520,
 				"width": 1000,
@@ -93,18 +172,37 @@ var (
 			"franchise":          24,
 			"game_status":        0,
 			"game_type":          0,
-			"genres":             []int{8},
-			"id":                 8008,
-			"name":               "1Xtreme (Greatest Hits)",
+			"franchises":         []map[string]interface{}{},
+			"genres": []map[string]interface{}{
+				{"id": 10, "name": "Racing"},
+				{"id": 14, "name": "Sport"},
+			},
+			"involved_companies": []map[string]interface{}{
+				{"id": 9001, "company": map[string]interface{}{"id": 1200, "name": "Sony Interactive Studios America"}, "developer": true, "publisher": false},
+				{"id": 9002, "company": map[string]interface{}{"id": 13, "name": "Sony Computer Entertainment America"}, "developer": false, "publisher": true},
+				{"id": 9003, "company": map[string]interface{}{"id": 1201, "name": "989 Studios"}, "developer": true, "publisher": false},
+			},
+			"id":   8008,
+			"name": "1Xtreme (Greatest Hits)",
 			"platforms": []map[string]interface{}{
 				{
 					"id":   7,
 					"name": "Playstation",
 				},
 			},
+			"screenshots": []map[string]interface{}{
+				{
+					"id":    9671,
+					"width": 889,
+					"url":   "//images.igdb.com/igdb/image/upload/t_thumb/sc7hl.jpg",
+				},
+			},
 			"storyline": "A storyline supplement from mocked IGDB data for test for 1Xtreme (Greatest Hits).",
 			"summary":   "A summary supplement from mocked IGDB data for test for 1Xtreme (Greatest Hits).",
-			"videos":    []int{35343, 20256},
+			"videos": []map[string]interface{}{
+				{"id": 35343, "name": "Trailer", "video_id": "mR6ZfUwPgqk"},
+				{"id": 20256, "name": "Gameplay video", "video_id": "VhBiaA7Ba3Q"},
+			},
 		},
 	}
 
@@ -157,13 +255,41 @@ func GetTestIGDBServer() *httptest.Server {
 		// 	t.Fail()
 		// }
 
+		// like IGDB, reject the whole request when a search term is not quoted and escaped
+		if strings.Count(string(body), "search ") != len(searchPattern.FindAllString(string(body), -1)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
 
-		if strings.Contains(string(body), "fields id, name, platforms") {
-			json.NewEncoder(w).Encode(testFuzzyFindGameDataResponse)
-		} else {
-			json.NewEncoder(w).Encode(testGetGameDataResponse)
+		if r.URL.Path == "/multiquery" {
+			results := []map[string]interface{}{}
+			for _, query := range multiqueryPattern.FindAllStringSubmatch(string(body), -1) {
+				results = append(results, map[string]interface{}{
+					"name":   query[1],
+					"result": gamesResponse(query[2]),
+				})
+			}
+			json.NewEncoder(w).Encode(results)
+			return
 		}
+
+		json.NewEncoder(w).Encode(gamesResponse(string(body)))
 	}))
 }
+
+// multiqueryPattern matches the named queries of a multiquery request body.
+var multiqueryPattern = regexp.MustCompile(`query games "([^"]+)" \{([^}]*)\};`)
+
+// searchPattern matches a search clause whose term has its quotes and backslashes escaped.
+var searchPattern = regexp.MustCompile(`search "(?:[^"\\]|\\.)*";`)
+
+func gamesResponse(query string) []map[string]interface{} {
+	if strings.Contains(query, "fields id, name, platforms") {
+		return testFuzzyFindGameDataResponse
+	}
+
+	return testGetGameDataResponse
+}
@@ -3,178 +3,313 @@ package igdb
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"main/src/domain"
 	"net/http"
-	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 )
 
-var (
-	igdbBaseUrl string
-	authToken   string
-	clientID    string
-)
-
-type authResponse struct {
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int    `json:"expires_in"`
-	TokenType   string `json:"token_type"`
+// Client is a client for the IGDB API. Each client holds its own configuration,
+// HTTP client, access token, response cache, rate limiter and retry policy, so
+// several clients can be used side by side.
+type Client struct {
+	baseUrl        string
+	clientID       string
+	httpClient     *http.Client
+	auth           *tokenSource
+	cache          *responseCache
+	overrides      domain.MatchOverrides
+	limiter        *rateLimiter
+	retry          retryPolicy
+	matchThreshold float64
+	output         io.Writer
 }
 
 type igdbFuzzySearchGameData struct {
-	ID        int    `json:"id"`
-	Name      string `json:"name"`
-	Platforms []int  `json:"platforms"`
+	ID                 int    `json:"id"`
+	Name               string `json:"name"`
+	Platforms          []int  `json:"platforms"`
+	First_release_date int    `json:"first_release_date"`
 }
 
-func retrieveAuthToken(baseUrl string, path string, id string, secret string) string {
-	request, _ := http.NewRequest(http.MethodPost, baseUrl+path, nil)
-	request.Header.Add("Content-Type", "application/json")
+func (c *Client) initIGDBRequestObject(path string, filter *strings.Reader, token string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, c.baseUrl+path, filter)
+	request.Header.Add("Client-ID", c.clientID)
+	request.Header.Add("Authorization", "Bearer "+token)
 
-	// Add query parameters
-	query := url.Values{}
-	query.Add("client_id", id)
-	query.Add("client_secret", secret)
-	query.Add("grant_type", "client_credentials")
-	request.URL.RawQuery = query.Encode()
-
-	// http client
-	httpClient := &http.Client{}
-	response, err := httpClient.Do(request)
+	return request
+}
 
-	if err != nil || response == nil || response.StatusCode != http.StatusOK {
-		return "failed"
+// doIGDBRequest posts the query to the IGDB endpoint and decodes the JSON
+// response into target. Responses are served from and stored in the disk
+// cache when one is configured.
+func (c *Client) doIGDBRequest(path string, query string, target interface{}) error {
+	if body, ok := c.cache.get(path, query); ok {
+		if err := json.Unmarshal(body, target); err == nil {
+			return nil
+		}
 	}
-	defer response.Body.Close()
 
-	var authRes authResponse
-	if err := json.NewDecoder(response.Body).Decode(&authRes); err != nil {
-		fmt.Printf("error decoding response body: %v\n", err)
-		return "failed"
+	body, err := c.requestWithRetry(path, query)
+	if err != nil {
+		return err
 	}
 
-	return authRes.AccessToken
-}
+	if err := json.Unmarshal(body, target); err != nil {
+		return &RequestError{Endpoint: path, StatusCode: http.StatusOK, Err: fmt.Errorf("error decoding response body: %w", err)}
+	}
 
-func initIGDBRequestObject(path string, filter *strings.Reader) *http.Request {
-	request, _ := http.NewRequest(http.MethodPost, igdbBaseUrl+path, filter)
-	request.Header.Add("Client-ID", clientID)
-	request.Header.Add("Authorization", "Bearer "+authToken)
+	if err := c.cache.put(path, query, body); err != nil {
+		fmt.Fprintf(c.output, "error caching IGDB response: %v\n", err)
+	}
 
-	return request
+	return nil
 }
 
-func getGameData(gameIDs []int) []IGDBGameData {
-	ids := strings.Join(strings.Fields(strings.Trim(fmt.Sprint(gameIDs), "[]")), ",")
-	query := fmt.Sprintf("fields *, platforms.name, cover.url, cover.width; where id = (%s);", ids)
-	request := initIGDBRequestObject("/games", strings.NewReader(query))
+// requestWithRetry posts the query to the IGDB endpoint and returns the response
+// body. Rate limited, server and network failures are retried with backoff
+// until the retry policy's attempt budget is used up.
+func (c *Client) requestWithRetry(path string, query string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		body, retryAfter, requestErr := c.sendIGDBRequest(path, query)
+		if requestErr == nil {
+			return body, nil
+		}
 
-	httpClient := &http.Client{}
-	response, err := httpClient.Do(request)
+		requestErr.Attempts = attempt
+		if attempt >= c.retry.maxAttempts || !c.retry.retryable(requestErr) {
+			return nil, requestErr
+		}
 
-	if err != nil || response == nil || response.StatusCode != http.StatusOK {
-		fmt.Printf("error getting game data: %v\n, %v", err, response)
-		return []IGDBGameData{}
+		delay := c.retry.delay(attempt, retryAfter)
+		fmt.Fprintf(c.output, "%v -- retrying in %v (attempt %d/%d)\n", requestErr, delay, attempt+1, c.retry.maxAttempts)
+		time.Sleep(delay)
 	}
-	defer response.Body.Close()
+}
 
-	var gameData []IGDBGameData
-	if err := json.NewDecoder(response.Body).Decode(&gameData); err != nil {
-		fmt.Printf("error decoding response body: %v\n", err)
-		return []IGDBGameData{}
-	}
+// sendIGDBRequest makes a single authorized request to the IGDB endpoint. A
+// token rejected by IGDB is refreshed and the request repeated once.
+func (c *Client) sendIGDBRequest(path string, query string) ([]byte, string, *RequestError) {
+	for refreshed := false; ; refreshed = true {
+		token, err := c.auth.token()
+		if err != nil {
+			return nil, "", &RequestError{Endpoint: path, Err: err}
+		}
+
+		body, retryAfter, requestErr := c.postIGDBQuery(path, query, token)
+		if requestErr != nil && requestErr.StatusCode == http.StatusUnauthorized && !refreshed {
+			fmt.Fprintf(c.output, "IGDB rejected the access token -- refreshing\n")
+			c.auth.invalidate(token)
+			continue
+		}
 
-	return gameData
+		return body, retryAfter, requestErr
+	}
 }
 
-func fuzzyFindIGDBGameByTitle(title string, clzPlatformName string) int {
-	// Normalize the game title
-	normalizedTitle := GameTitleNormalization(title)
+// postIGDBQuery posts the query to the IGDB endpoint within the rate limit and
+// returns the response body, or the failure along with the response's
+// Retry-After header.
+func (c *Client) postIGDBQuery(path string, query string, token string) ([]byte, string, *RequestError) {
+	request := c.initIGDBRequestObject(path, strings.NewReader(query), token)
 
-	fmt.Printf("FuzzyFind for title: %s\n", normalizedTitle)
+	release := c.limiter.acquire()
+	defer release()
 
-	// Search for the game by normalized title
-	gamesData := fuzzySearchByTerm(normalizedTitle)
-	if len(gamesData) == 0 {
-		fmt.Printf("FuzzyFind failed for title: %s\n", normalizedTitle)
-		return 0
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, "", &RequestError{Endpoint: path, Err: err}
 	}
+	defer response.Body.Close()
 
-	// if multiple games returned, find the entry with a matching platform
-	if len(gamesData) > 1 {
-		for _, game := range gamesData {
-			fmt.Printf("FuzzyFind found game: %s\n", game.Name)
-			for _, platform := range game.Platforms {
-				fmt.Printf("Platform: %s\n", domain.PlatformMap.IGDBToCLZ[platform])
-				if platform == domain.PlatformMap.CLZToIGDB[clzPlatformName] {
-					return game.ID
-				}
-			}
-		}
+	if response.StatusCode != http.StatusOK {
+		return nil, response.Header.Get("Retry-After"), &RequestError{Endpoint: path, StatusCode: response.StatusCode}
 	}
 
-	// Return the first game found
-	return gamesData[0].ID
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", &RequestError{Endpoint: path, StatusCode: response.StatusCode, Err: fmt.Errorf("error reading response body: %w", err)}
+	}
+
+	return body, "", nil
 }
 
-func fuzzySearchByTerm(searchTerm string) []igdbFuzzySearchGameData {
-	request := initIGDBRequestObject("/games", strings.NewReader(fmt.Sprintf("search \"%s\"; fields id, name, platforms;", searchTerm)))
+// GetGameData retrieves the game details for the game IDs.
+//
+// Parameters:
+//   - gameIDs: The ID int values of the games.
+//
+// Returns:
+//   - The IGDBGameData of the requested games.
+//   - error: A *RequestError if the IGDB request fails, otherwise nil.
+func (c *Client) GetGameData(gameIDs []int) ([]IGDBGameData, error) {
+	var gameData []IGDBGameData
+	if err := c.doIGDBRequest("/games", gameDataQuery(gameIDs), &gameData); err != nil {
+		return []IGDBGameData{}, err
+	}
 
-	httpClient := &http.Client{}
-	response, err := httpClient.Do(request)
+	return gameData, nil
+}
 
-	if err != nil || response == nil || response.StatusCode != http.StatusOK {
-		fmt.Printf("error getting game data: %v\n, %v", err, response)
-		return []igdbFuzzySearchGameData{}
+// FuzzyFindGameByTitle searches IGDB for a game by title and returns the ID of the best
+// scored result for the title and platform.
+//
+// Parameters:
+//   - title: The game title string.
+//   - clzPlatformName: The CLZ platform name string.
+//
+// Returns:
+//   - The ID int value of the best scored game.
+//   - error: ErrNoMatch, a *LowConfidenceError or a *RequestError if no game was matched.
+func (c *Client) FuzzyFindGameByTitle(title string, clzPlatformName string) (int, error) {
+	fmt.Fprintf(c.output, "FuzzyFind for title: %s\n", GameTitleNormalization(title))
+
+	match, err := c.findIGDBGameMatch(domain.Game{Title: title, Platform: domain.Platform(clzPlatformName)})
+	if err != nil {
+		fmt.Fprintf(c.output, "FuzzyFind failed for title: %s: %v\n", title, err)
+		return 0, err
 	}
-	defer response.Body.Close()
 
-	var searchResults []igdbFuzzySearchGameData
+	fmt.Fprintf(c.output, "FuzzyFind matched game: %s (confidence %.2f)\n", match.Name, match.Confidence)
+	return match.ID, nil
+}
 
-	if err := json.NewDecoder(response.Body).Decode(&searchResults); err != nil {
-		fmt.Printf("error decoding response body: %v\n", err)
-		return []igdbFuzzySearchGameData{}
+func (c *Client) fuzzySearchByTerm(searchTerm string) ([]igdbFuzzySearchGameData, error) {
+	var searchResults []igdbFuzzySearchGameData
+	if err := c.doIGDBRequest("/games", searchQuery(searchTerm), &searchResults); err != nil {
+		return []igdbFuzzySearchGameData{}, err
 	}
 
-	return searchResults
+	return searchResults, nil
 }
 
-func fuzzyFindGamesList(gameList []domain.Game) []domain.Game {
-	// Rate limit duration for IGDB API
-	rateLimitStr := os.Getenv("IGDB_API_RATE_LIMIT")
-	rateLimit, err := strconv.Atoi(rateLimitStr)
-	if err != nil {
-		fmt.Printf("Invalid IGDB_API_RATE_LIMIT value: %v -- setting to 0\n", err)
-		rateLimit = 0 // Default to 0 second if parsing fails
-	}
+// gameDataQuery returns the query for the details of the games, including the
+// names of the entities they reference. At most MaxQueryResults IDs fit in a query.
+func gameDataQuery(gameIDs []int) string {
+	ids := strings.Join(strings.Fields(strings.Trim(fmt.Sprint(gameIDs), "[]")), ",")
+	return fmt.Sprintf("fields *, platforms.name, cover.url, cover.width, genres.name, franchises.name, "+
+		"involved_companies.company.name, involved_companies.developer, involved_companies.publisher, "+
+		"artworks.url, artworks.width, screenshots.url, screenshots.width, videos.name, videos.video_id; "+
+		"where id = (%s); limit %d;", ids, len(gameIDs))
+}
+
+// searchTermEscaper escapes a search term for a quoted IGDB string, so a
+// title with quotes cannot break its query, or the multiquery request around it.
+var searchTermEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
 
-	sleepTime := time.Duration(rateLimit) * time.Millisecond
+func searchQuery(searchTerm string) string {
+	return fmt.Sprintf("search \"%s\"; fields id, name, platforms, first_release_date;", searchTermEscaper.Replace(searchTerm))
+}
 
+// FuzzyFindGamesList matches every game in the list to an IGDB ID. Games with a match
+// override are pinned to the overridden IGDB ID, or skipped, without searching. The
+// searches for the other games are packed into multiquery requests.
+//
+// Parameters:
+//   - gameList: The game collection list.
+//
+// Returns:
+//   - The games list with entries updated with IGDB ID values.
+//   - A slice of errors aligned with the games list, holding the lookup error for each game left
+//     unmatched, ErrEnrichmentSkipped for games skipped by an override.
+func (c *Client) FuzzyFindGamesList(gameList []domain.Game) ([]domain.Game, []error) {
+	lookupErrors := make([]error, len(gameList))
+
+	searches := []int{}
 	for i, game := range gameList {
-		if i != 0 {
-			fmt.Printf("%d Sleeping for rate limit...\n", i)
-			// Sleep for a short duration to avoid hitting the rate limit
-			time.Sleep(sleepTime)
+		// manual overrides take precedence over any search
+		override, ok := c.overrides.Find(game)
+		if !ok {
+			searches = append(searches, i)
+			continue
 		}
 
-		// Normalize the game title
-		normalizedTitle := GameTitleNormalization(game.Title)
+		if override.Skip {
+			fmt.Fprintf(c.output, "Skipping IGDB match for title: %s (override)\n", game.Title)
+			gameList[i].IGDB_ID = 0
+			lookupErrors[i] = ErrEnrichmentSkipped
+		} else {
+			fmt.Fprintf(c.output, "Using IGDB_ID %d for title: %s (override)\n", override.IGDB_ID, game.Title)
+			gameList[i].IGDB_ID = override.IGDB_ID
+		}
+	}
 
-		// Search for the game by normalized title
-		gameIgdbId := fuzzyFindIGDBGameByTitle(normalizedTitle, string(game.Platform))
-		if gameIgdbId == 0 {
-			fmt.Printf("No games found in FuzzyFind for title: %s\n", game.Title)
-			continue
+	// every worker writes to the game indexes of its own chunk only, and the
+	// shared limiter keeps the requests within the IGDB rate limit
+	chunks := chunkIndexes(searches, MultiqueryLimit)
+	runConcurrently(len(chunks), c.limiter.workerCount(len(chunks)), func(chunk int) {
+		indexes := chunks[chunk]
+		queries := make([]string, len(indexes))
+		targets := make([]interface{}, len(indexes))
+		searchResults := make([][]igdbFuzzySearchGameData, len(indexes))
+		for j, i := range indexes {
+			queries[j] = searchQuery(GameTitleNormalization(gameList[i].Title))
+			targets[j] = &searchResults[j]
 		}
 
-		// Update the game ID in the game list
-		gameList[i].IGDB_ID = gameIgdbId
+		searchErrors := c.doIGDBMultiquery("games", queries, targets)
+
+		for j, i := range indexes {
+			// Score the search results against the game
+			err := searchErrors[j]
+			var match MatchCandidate
+			if err == nil {
+				match, err = c.selectMatch(rankCandidates(gameList[i], searchResults[j]))
+			}
+			if err != nil {
+				fmt.Fprintf(c.output, "No match found in FuzzyFind for title: %s: %v\n", gameList[i].Title, err)
+				lookupErrors[i] = err
+				continue
+			}
+
+			// Update the game ID in the game list
+			gameList[i].IGDB_ID = match.ID
+		}
+	})
+
+	return gameList, lookupErrors
+}
+
+// GetGameDataBatches retrieves the game details for several batches of game IDs
+// concurrently, within the client's rate limit. The batches are packed into
+// multiquery requests, so each batch should hold at most MaxQueryResults IDs.
+//
+// Parameters:
+//   - batches: The batches of game ID int values.
+//
+// Returns:
+//   - The IGDBGameData retrieved for each batch, in batch order.
+//   - A slice of errors aligned with the batches, holding a *RequestError for each failed batch.
+func (c *Client) GetGameDataBatches(batches [][]int) ([][]IGDBGameData, []error) {
+	results := make([][]IGDBGameData, len(batches))
+	batchErrors := make([]error, len(batches))
+
+	batchIndexes := make([]int, len(batches))
+	for i := range batches {
+		batchIndexes[i] = i
 	}
 
-	return gameList
+	chunks := chunkIndexes(batchIndexes, MultiqueryLimit)
+	runConcurrently(len(chunks), c.limiter.workerCount(len(chunks)), func(chunk int) {
+		indexes := chunks[chunk]
+		fmt.Fprintf(c.output, "Processing batches %d-%d/%d...\n", indexes[0]+1, indexes[len(indexes)-1]+1, len(batches))
+
+		queries := make([]string, len(indexes))
+		targets := make([]interface{}, len(indexes))
+		for j, i := range indexes {
+			queries[j] = gameDataQuery(batches[i])
+			targets[j] = &results[i]
+		}
+
+		for j, err := range c.doIGDBMultiquery("games", queries, targets) {
+			batchErrors[indexes[j]] = err
+		}
+	})
+
+	return results, batchErrors
 }
 
 // GameTitleNormalization normalizes the game title by removing special characters and converting to lowercase.
@@ -192,8 +327,61 @@ func GameTitleNormalization(title string) string {
 	return normalizedTitle
 }
 
-// NewIGDBAdapter initializes a new IGDBAdapter with the provided authentication details.
-// Authenticates with the IGDB API with an access token.
+// IGDBAdapterInitFromEnv returns the IGDBAdapterInit authentication and API
+// settings configured through the IGDB_* environment variables.
+//
+// Returns:
+//   - An IGDBAdapterInit with the authentication details, IGDB base URL and request limits set.
+//
+// IGDB_API_RATE_LIMIT is the minimum number of milliseconds between IGDB requests,
+// defaulting to DefaultRateLimit, and IGDB_API_CONCURRENCY the maximum number of
+// requests in flight, defaulting to DefaultMaxConcurrency. IGDB_API_MAX_ATTEMPTS
+// is the number of times a failing request is attempted, defaulting to DefaultMaxAttempts.
+func IGDBAdapterInitFromEnv() IGDBAdapterInit {
+	rateLimit := DefaultRateLimit
+	if rateLimitStr := os.Getenv("IGDB_API_RATE_LIMIT"); rateLimitStr != "" {
+		milliseconds, err := strconv.Atoi(rateLimitStr)
+		if err != nil || milliseconds < 0 {
+			fmt.Fprintf(os.Stderr, "Invalid IGDB_API_RATE_LIMIT value: %q -- using %v\n", rateLimitStr, DefaultRateLimit)
+		} else {
+			rateLimit = time.Duration(milliseconds) * time.Millisecond
+		}
+	}
+
+	concurrency := DefaultMaxConcurrency
+	if concurrencyStr := os.Getenv("IGDB_API_CONCURRENCY"); concurrencyStr != "" {
+		parsed, err := strconv.Atoi(concurrencyStr)
+		if err != nil || parsed <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid IGDB_API_CONCURRENCY value: %q -- using %d\n", concurrencyStr, DefaultMaxConcurrency)
+		} else {
+			concurrency = parsed
+		}
+	}
+
+	maxAttempts := DefaultMaxAttempts
+	if maxAttemptsStr := os.Getenv("IGDB_API_MAX_ATTEMPTS"); maxAttemptsStr != "" {
+		parsed, err := strconv.Atoi(maxAttemptsStr)
+		if err != nil || parsed <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid IGDB_API_MAX_ATTEMPTS value: %q -- using %d\n", maxAttemptsStr, DefaultMaxAttempts)
+		} else {
+			maxAttempts = parsed
+		}
+	}
+
+	return IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+		RateLimit:        rateLimit,
+		MaxConcurrency:   concurrency,
+		MaxAttempts:      maxAttempts,
+	}
+}
+
+// NewClient creates an IGDB API client and authenticates it with an access token,
+// reusing the token persisted in the cache directory while it is valid.
 //
 // Parameters:
 //   - init: IGDBAdapterInit struct containing the following fields:
@@ -201,17 +389,81 @@ func GameTitleNormalization(title string) string {
 //   - AuthUrlPath: The URL path for the authentication endpoint.
 //   - AuthClientId: The client ID for authentication.
 //   - AuthClientSecret: The client secret for authentication.
+//   - IGDBBaseUrl: The base URL of the IGDB API.
+//   - HTTPClient: The HTTP client to make requests with, a new client when nil.
+//   - CacheDir: The directory IGDB responses and the access token are cached in, caching is disabled when empty.
+//   - CacheTTL: The time cached responses are reused for.
+//   - RefreshCache: Whether to ignore cached responses and re-query IGDB.
+//   - MatchThreshold: The minimum confidence for a fuzzy match to be accepted.
+//   - Overrides: Manual match decisions consulted before searching for a game.
+//   - RateLimit: The minimum interval between IGDB requests, zero disables rate limiting.
+//   - MaxConcurrency: The maximum number of IGDB requests in flight.
+//   - MaxAttempts: The number of times a failing IGDB request is attempted.
+//   - RetryBaseDelay: The backoff before the first retry, doubled for every further retry.
+//   - Output: The writer progress and failed lookups are printed to, os.Stdout when nil.
 //
 // Returns:
-//   - A pointer to an IGDBAdapter instance with the retrieved authentication token and a function to get game data.
-func NewIGDBAdapter(init IGDBAdapterInit) *IGDBAdapter {
-	authToken = retrieveAuthToken(init.AuthBaseUrl, init.AuthUrlPath, init.AuthClientId, init.AuthClientSecret)
-	clientID = init.AuthClientId
-	igdbBaseUrl = init.IGDBBaseUrl
+//   - A pointer to a Client instance.
+//   - An *AuthError if no access token could be obtained, in which case no IGDB queries are made.
+func NewClient(init IGDBAdapterInit) (*Client, error) {
+	httpClient := init.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	output := init.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	client := &Client{
+		baseUrl:        init.IGDBBaseUrl,
+		clientID:       init.AuthClientId,
+		httpClient:     httpClient,
+		auth:           newTokenSource(init, httpClient, output),
+		cache:          newResponseCache(init.CacheDir, init.IGDBBaseUrl, init.CacheTTL, init.RefreshCache),
+		overrides:      init.Overrides,
+		limiter:        newRateLimiter(init.RateLimit, init.MaxConcurrency),
+		retry:          newRetryPolicy(init.MaxAttempts, init.RetryBaseDelay),
+		matchThreshold: DefaultMatchThreshold,
+		output:         output,
+	}
+	if init.MatchThreshold != nil {
+		client.matchThreshold = *init.MatchThreshold
+	}
 
+	if _, err := client.auth.token(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Adapter returns an IGDBAdapter whose functions are backed by the client.
+func (c *Client) Adapter() *IGDBAdapter {
 	return &IGDBAdapter{
-		GetGameData:          func(gameIDs []int) []IGDBGameData { return getGameData(gameIDs) },
-		FuzzyFindGameByTitle: func(title string, clzPlatform string) int { return fuzzyFindIGDBGameByTitle(title, clzPlatform) },
-		FuzzyFindGamesList:   func(gameList []domain.Game) []domain.Game { return fuzzyFindGamesList(gameList) },
+		GetGameData:          c.GetGameData,
+		FuzzyFindGameByTitle: c.FuzzyFindGameByTitle,
+		FuzzyFindGamesList:   c.FuzzyFindGamesList,
+		RankGameCandidates:   c.RankGameCandidates,
+		GetGameDataBatches:   c.GetGameDataBatches,
+	}
+}
+
+// NewIGDBAdapter initializes a new IGDBAdapter backed by its own Client, see NewClient
+// for the initialization parameters.
+//
+// Parameters:
+//   - init: IGDBAdapterInit struct containing the client configuration.
+//
+// Returns:
+//   - A pointer to an IGDBAdapter instance with the retrieved authentication token and a function to get game data.
+//   - An *AuthError if no access token could be obtained, in which case no IGDB queries are made.
+func NewIGDBAdapter(init IGDBAdapterInit) (*IGDBAdapter, error) {
+	client, err := NewClient(init)
+	if err != nil {
+		return nil, err
 	}
+
+	return client.Adapter(), nil
 }
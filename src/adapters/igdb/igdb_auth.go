@@ -0,0 +1,195 @@
+package igdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// tokenFileName is the name of the file the Twitch access token is persisted
+// to within the IGDB cache directory.
+const tokenFileName = "twitch-token.json"
+
+// tokenExpiryMargin is how long before its expiry a token is refreshed, so it
+// does not expire while requests using it are in flight.
+const tokenExpiryMargin = time.Minute
+
+type authResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+// storedToken is a Twitch access token along with the client it was issued to
+// and the time it expires.
+type storedToken struct {
+	ClientID    string    `json:"client_id"`
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (t storedToken) valid(clientID string, now time.Time) bool {
+	return t.AccessToken != "" && t.ClientID == clientID && now.Add(tokenExpiryMargin).Before(t.ExpiresAt)
+}
+
+// tokenSource hands out a valid Twitch access token, reusing the persisted
+// token across runs and requesting a new one when it expires or is rejected.
+type tokenSource struct {
+	mu           sync.Mutex
+	baseUrl      string
+	path         string
+	clientID     string
+	clientSecret string
+	file         string
+	httpClient   *http.Client
+	output       io.Writer
+	current      storedToken
+}
+
+// newTokenSource creates a token source for the client, persisting tokens in
+// the cache directory unless it is empty.
+func newTokenSource(init IGDBAdapterInit, httpClient *http.Client, output io.Writer) *tokenSource {
+	source := &tokenSource{
+		baseUrl:      init.AuthBaseUrl,
+		path:         init.AuthUrlPath,
+		clientID:     init.AuthClientId,
+		clientSecret: init.AuthClientSecret,
+		httpClient:   httpClient,
+		output:       output,
+	}
+	if init.CacheDir != "" {
+		source.file = filepath.Join(init.CacheDir, tokenFileName)
+	}
+
+	return source
+}
+
+// token returns the current access token, loading the persisted token or
+// requesting a new one when there is no valid token.
+func (s *tokenSource) token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.current.valid(s.clientID, now) {
+		return s.current.AccessToken, nil
+	}
+
+	if stored, ok := s.load(); ok && stored.valid(s.clientID, now) {
+		s.current = stored
+		return s.current.AccessToken, nil
+	}
+
+	fetched, err := retrieveAuthToken(s.httpClient, s.baseUrl, s.path, s.clientID, s.clientSecret)
+	if err != nil {
+		return "", err
+	}
+
+	s.current = fetched
+	if err := s.save(); err != nil {
+		fmt.Fprintf(s.output, "error caching Twitch access token: %v\n", err)
+	}
+
+	return s.current.AccessToken, nil
+}
+
+// invalidate discards a token rejected by IGDB so the next call to token
+// requests a new one. Tokens already replaced by another request are ignored.
+func (s *tokenSource) invalidate(rejected string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current.AccessToken != rejected {
+		return
+	}
+
+	s.current = storedToken{}
+	if s.file != "" {
+		os.Remove(s.file)
+	}
+}
+
+func (s *tokenSource) load() (storedToken, bool) {
+	if s.file == "" {
+		return storedToken{}, false
+	}
+
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return storedToken{}, false
+	}
+
+	var stored storedToken
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return storedToken{}, false
+	}
+
+	return stored, true
+}
+
+func (s *tokenSource) save() error {
+	if s.file == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.file), fs.FileMode(0755)); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s.current)
+	if err != nil {
+		return err
+	}
+
+	// the token grants access to the IGDB API, so keep it private to the user
+	return os.WriteFile(s.file, data, fs.FileMode(0600))
+}
+
+// retrieveAuthToken requests a client credentials access token from Twitch.
+func retrieveAuthToken(httpClient *http.Client, baseUrl string, path string, id string, secret string) (storedToken, error) {
+	request, err := http.NewRequest(http.MethodPost, baseUrl+path, nil)
+	if err != nil {
+		return storedToken{}, &AuthError{Err: err}
+	}
+	request.Header.Add("Content-Type", "application/json")
+
+	// Add query parameters
+	query := url.Values{}
+	query.Add("client_id", id)
+	query.Add("client_secret", secret)
+	query.Add("grant_type", "client_credentials")
+	request.URL.RawQuery = query.Encode()
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return storedToken{}, &AuthError{Err: err}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return storedToken{}, &AuthError{StatusCode: response.StatusCode}
+	}
+
+	var authRes authResponse
+	if err := json.NewDecoder(response.Body).Decode(&authRes); err != nil {
+		return storedToken{}, &AuthError{StatusCode: response.StatusCode, Err: fmt.Errorf("error decoding response body: %w", err)}
+	}
+
+	if authRes.AccessToken == "" {
+		return storedToken{}, &AuthError{StatusCode: response.StatusCode, Err: errors.New("no access token in response")}
+	}
+
+	return storedToken{
+		ClientID:    id,
+		AccessToken: authRes.AccessToken,
+		ExpiresAt:   time.Now().Add(time.Duration(authRes.ExpiresIn) * time.Second),
+	}, nil
+}
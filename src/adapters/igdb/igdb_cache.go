@@ -0,0 +1,118 @@
+package igdb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is the time a cached IGDB response is reused for when no TTL is configured.
+const DefaultCacheTTL = 7 * 24 * time.Hour
+
+// responseCache stores raw IGDB responses on disk, keyed by IGDB base URL,
+// endpoint and query body, so that repeated runs only query IGDB for new or
+// expired entries. Keying by base URL keeps a mock or proxy server sharing the
+// cache directory from serving its responses for the production API.
+type responseCache struct {
+	dir     string
+	baseURL string
+	ttl     time.Duration
+	refresh bool
+}
+
+type cacheEntry struct {
+	BaseURL  string          `json:"base_url"`
+	Endpoint string          `json:"endpoint"`
+	Query    string          `json:"query"`
+	StoredAt time.Time       `json:"stored_at"`
+	Body     json.RawMessage `json:"body"`
+}
+
+func newResponseCache(dir string, baseURL string, ttl time.Duration, refresh bool) *responseCache {
+	if dir == "" {
+		return nil
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &responseCache{dir: dir, baseURL: baseURL, ttl: ttl, refresh: refresh}
+}
+
+func (c *responseCache) path(endpoint string, query string) string {
+	sum := sha256.Sum256([]byte(c.baseURL + "\n" + endpoint + "\n" + query))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// get returns the cached response body for the endpoint and query, if one
+// exists and has not expired. Refreshing caches never return a hit.
+func (c *responseCache) get(endpoint string, query string) ([]byte, bool) {
+	if c == nil || c.refresh {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(endpoint, query))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	// guard against hash collisions and entries written for another query
+	if entry.BaseURL != c.baseURL || entry.Endpoint != endpoint || entry.Query != query {
+		return nil, false
+	}
+
+	if time.Since(entry.StoredAt) > c.ttl {
+		return nil, false
+	}
+
+	return entry.Body, true
+}
+
+// put stores the response body for the endpoint and query. The entry is
+// written to a temporary file first so an interrupted run never leaves a
+// truncated entry behind.
+func (c *responseCache) put(endpoint string, query string, body []byte) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, fs.FileMode(0755)); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cacheEntry{
+		BaseURL:  c.baseURL,
+		Endpoint: endpoint,
+		Query:    query,
+		StoredAt: time.Now(),
+		Body:     body,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path(endpoint, query))
+}
@@ -0,0 +1,65 @@
+package igdb
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoMatch is returned when an IGDB search does not return any game for a title.
+var ErrNoMatch = errors.New("no matching game found in IGDB")
+
+// ErrEnrichmentSkipped is returned for a game excluded from IGDB enrichment by a match override.
+var ErrEnrichmentSkipped = errors.New("IGDB enrichment skipped by override")
+
+// AuthError describes a failure to obtain a Twitch access token for the IGDB API.
+//
+// Fields:
+//   - StatusCode: The HTTP status code of the response, zero if no response was received.
+//   - Err: The underlying error, if any.
+type AuthError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *AuthError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("Twitch authentication failed: %v", e.Err)
+	}
+
+	return fmt.Sprintf("Twitch authentication failed with status %d", e.StatusCode)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// RequestError describes a failed request to the IGDB API.
+//
+// Fields:
+//   - Endpoint: The IGDB API path that was requested.
+//   - StatusCode: The HTTP status code of the response, zero if no response was received.
+//   - Attempts: The number of times the request was attempted.
+//   - Err: The underlying error, if any.
+type RequestError struct {
+	Endpoint   string
+	StatusCode int
+	Attempts   int
+	Err        error
+}
+
+func (e *RequestError) Error() string {
+	message := fmt.Sprintf("IGDB request to %s failed with status %d", e.Endpoint, e.StatusCode)
+	if e.Err != nil {
+		message = fmt.Sprintf("IGDB request to %s failed: %v", e.Endpoint, e.Err)
+	}
+
+	if e.Attempts > 1 {
+		message += fmt.Sprintf(" after %d attempts", e.Attempts)
+	}
+
+	return message
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
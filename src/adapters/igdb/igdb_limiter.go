@@ -0,0 +1,115 @@
+package igdb
+
+import (
+	"sync"
+	"time"
+)
+
+// IGDB allows 4 requests per second and up to 8 open requests.
+const (
+	DefaultRateLimit      = 250 * time.Millisecond
+	DefaultMaxConcurrency = 8
+)
+
+// rateLimiter is a token bucket limiting how often IGDB requests are started,
+// combined with a cap on how many requests may be in flight at once. A single
+// limiter is shared by every request the adapter makes.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	slots    chan struct{}
+}
+
+// newRateLimiter creates a limiter starting a request at most every interval
+// with at most maxConcurrency requests in flight. An interval of zero disables
+// rate limiting, leaving only the concurrency cap.
+func newRateLimiter(interval time.Duration, maxConcurrency int) *rateLimiter {
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+
+	return &rateLimiter{
+		interval: interval,
+		burst:    1,
+		tokens:   1,
+		last:     time.Now(),
+		slots:    make(chan struct{}, maxConcurrency),
+	}
+}
+
+// acquire blocks until a request may be started and returns the function
+// releasing its concurrency slot once the request has completed.
+func (l *rateLimiter) acquire() func() {
+	if l == nil {
+		return func() {}
+	}
+
+	l.slots <- struct{}{}
+	time.Sleep(l.reserve())
+
+	return func() { <-l.slots }
+}
+
+// reserve takes a token from the bucket and returns how long the caller has
+// to wait for it. Tokens may be taken ahead of time, leaving the bucket in
+// debt, so that concurrent callers are spaced out by the interval.
+func (l *rateLimiter) reserve() time.Duration {
+	if l.interval <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// workerCount returns the number of workers to run jobs with, bounded by the
+// limiter's concurrency so workers do not just queue for request slots.
+func (l *rateLimiter) workerCount(jobs int) int {
+	workers := DefaultMaxConcurrency
+	if l != nil {
+		workers = cap(l.slots)
+	}
+
+	return max(min(workers, jobs), 1)
+}
+
+// runConcurrently calls job for every index from 0 to count-1 on a pool of
+// workers and waits for all of them to finish.
+func runConcurrently(count int, workers int, job func(int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				job(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+}
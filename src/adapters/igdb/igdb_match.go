@@ -0,0 +1,256 @@
+package igdb
+
+import (
+	"fmt"
+	"main/src/domain"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultMatchThreshold is the minimum confidence a candidate needs to be
+// accepted as the IGDB match for a game when no threshold is configured.
+const DefaultMatchThreshold = 0.6
+
+// The weights of each signal in a candidate's confidence, summing to 1.
+const (
+	nameWeight     = 0.6
+	platformWeight = 0.25
+	yearWeight     = 0.15
+
+	// maxYearDistance is the release year difference at which the year signal bottoms out.
+	maxYearDistance = 5
+)
+
+// MatchCandidate is an IGDB search result scored against a CLZ game.
+//
+// Fields:
+//   - ID: The unique ID value of the IGDB game.
+//   - Name: The name of the IGDB game.
+//   - Platforms: The IGDB platform IDs the game was released on.
+//   - ReleaseYear: The year of the game's first release, zero if unknown.
+//   - Confidence: How closely the candidate matches the CLZ game, from 0 to 1.
+type MatchCandidate struct {
+	ID          int
+	Name        string
+	Platforms   []int
+	ReleaseYear int
+	Confidence  float64
+}
+
+// LowConfidenceError is returned when no search result reaches the match
+// threshold. It matches ErrNoMatch with errors.Is.
+//
+// Fields:
+//   - Candidates: The ranked search results, best first.
+//   - Threshold: The confidence the best candidate needed to reach.
+type LowConfidenceError struct {
+	Candidates []MatchCandidate
+	Threshold  float64
+}
+
+func (e *LowConfidenceError) Error() string {
+	if len(e.Candidates) == 0 {
+		return ErrNoMatch.Error()
+	}
+
+	best := e.Candidates[0]
+	return fmt.Sprintf("best IGDB match %q (%d) has confidence %.2f, below threshold %.2f", best.Name, best.ID, best.Confidence, e.Threshold)
+}
+
+func (e *LowConfidenceError) Is(target error) bool {
+	return target == ErrNoMatch
+}
+
+// normalizeForComparison lowercases the title and collapses whitespace so that
+// cosmetic differences do not count against the name similarity.
+func normalizeForComparison(title string) string {
+	return strings.Join(strings.Fields(GameTitleNormalization(title)), " ")
+}
+
+func titleTokens(title string) []string {
+	return strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func levenshteinDistance(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// editSimilarity is the Levenshtein distance between two titles normalized to
+// a similarity between 0 (nothing in common) and 1 (identical).
+func editSimilarity(a string, b string) float64 {
+	runesA, runesB := []rune(a), []rune(b)
+	longest := max(len(runesA), len(runesB))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshteinDistance(runesA, runesB))/float64(longest)
+}
+
+// tokenOverlap is the Jaccard index of the words in two titles.
+func tokenOverlap(a string, b string) float64 {
+	tokensA, tokensB := titleTokens(a), titleTokens(b)
+	if len(tokensA) == 0 && len(tokensB) == 0 {
+		return 1
+	}
+
+	set := map[string]bool{}
+	for _, token := range tokensA {
+		set[token] = true
+	}
+
+	union := len(set)
+	intersection := 0
+	seen := map[string]bool{}
+	for _, token := range tokensB {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+
+		if set[token] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+
+	return float64(intersection) / float64(union)
+}
+
+func nameScore(clzTitle string, igdbName string) float64 {
+	a, b := normalizeForComparison(clzTitle), normalizeForComparison(igdbName)
+	return (editSimilarity(a, b) + tokenOverlap(a, b)) / 2
+}
+
+// platformScore is 1 if the candidate was released on the CLZ platform, 0 if
+// not, and neutral when the CLZ platform has no IGDB mapping.
+func platformScore(clzPlatform domain.Platform, igdbPlatforms []int) float64 {
+	platformID, ok := domain.PlatformMap.IGDBPlatformID(string(clzPlatform))
+	if !ok {
+		return 0.5
+	}
+
+	for _, platform := range igdbPlatforms {
+		if platform == platformID {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// yearScore decreases linearly with the distance between release years, and
+// is neutral when either year is unknown.
+func yearScore(clzYear int, igdbYear int) float64 {
+	if clzYear == 0 || igdbYear == 0 {
+		return 0.5
+	}
+
+	distance := math.Abs(float64(clzYear - igdbYear))
+	return 1 - math.Min(distance, maxYearDistance)/maxYearDistance
+}
+
+func releaseYear(unixTimestamp int) int {
+	if unixTimestamp == 0 {
+		return 0
+	}
+
+	return time.Unix(int64(unixTimestamp), 0).UTC().Year()
+}
+
+// rankCandidates scores every search result against the game and returns them
+// ordered by descending confidence.
+func rankCandidates(game domain.Game, searchResults []igdbFuzzySearchGameData) []MatchCandidate {
+	candidates := make([]MatchCandidate, 0, len(searchResults))
+
+	for _, result := range searchResults {
+		year := releaseYear(result.First_release_date)
+		confidence := nameWeight*nameScore(game.Title, result.Name) +
+			platformWeight*platformScore(game.Platform, result.Platforms) +
+			yearWeight*yearScore(game.ReleaseDate.Year(), year)
+
+		candidates = append(candidates, MatchCandidate{
+			ID:          result.ID,
+			Name:        result.Name,
+			Platforms:   result.Platforms,
+			ReleaseYear: year,
+			Confidence:  confidence,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	return candidates
+}
+
+// RankGameCandidates searches IGDB for a game and scores every result against
+// its title, platform and release date.
+//
+// Parameters:
+//   - game: The game to find candidates for.
+//
+// Returns:
+//   - The candidates ordered by descending confidence.
+//   - error: A *RequestError if the IGDB request fails.
+func (c *Client) RankGameCandidates(game domain.Game) ([]MatchCandidate, error) {
+	normalizedTitle := GameTitleNormalization(game.Title)
+
+	searchResults, err := c.fuzzySearchByTerm(normalizedTitle)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankCandidates(game, searchResults), nil
+}
+
+// findIGDBGameMatch returns the best ranked candidate for the game, provided
+// it reaches the match threshold.
+func (c *Client) findIGDBGameMatch(game domain.Game) (MatchCandidate, error) {
+	candidates, err := c.RankGameCandidates(game)
+	if err != nil {
+		return MatchCandidate{}, err
+	}
+
+	return c.selectMatch(candidates)
+}
+
+// selectMatch returns the first of the ranked candidates, provided it reaches
+// the match threshold.
+func (c *Client) selectMatch(candidates []MatchCandidate) (MatchCandidate, error) {
+	if len(candidates) == 0 {
+		return MatchCandidate{}, ErrNoMatch
+	}
+
+	if candidates[0].Confidence < c.matchThreshold {
+		return MatchCandidate{}, &LowConfidenceError{Candidates: candidates, Threshold: c.matchThreshold}
+	}
+
+	return candidates[0], nil
+}
@@ -0,0 +1,103 @@
+package igdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// IGDB accepts up to 10 named queries per multiquery request, and returns at
+// most 500 results for a single query.
+const (
+	MultiqueryLimit = 10
+	MaxQueryResults = 500
+)
+
+type multiqueryResult struct {
+	Name   string          `json:"name"`
+	Result json.RawMessage `json:"result"`
+}
+
+// doIGDBMultiquery runs up to MultiqueryLimit queries against the IGDB
+// endpoint in a single multiquery request and decodes the result of every
+// query into the target at the same index. Each query is cached on its own, as
+// if it had been requested directly, so only queries missing from the cache
+// are sent.
+//
+// Returns:
+//   - A slice of errors aligned with the queries, holding a *RequestError for each failed query.
+func (c *Client) doIGDBMultiquery(endpoint string, queries []string, targets []interface{}) []error {
+	queryErrors := make([]error, len(queries))
+	path := "/" + endpoint
+
+	pending := []int{}
+	for i, query := range queries {
+		if body, ok := c.cache.get(path, query); ok {
+			if err := json.Unmarshal(body, targets[i]); err == nil {
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+
+	if len(pending) == 0 {
+		return queryErrors
+	}
+
+	var multiquery strings.Builder
+	for _, i := range pending {
+		fmt.Fprintf(&multiquery, "query %s \"%d\" {\n%s\n};\n", endpoint, i, queries[i])
+	}
+
+	body, err := c.requestWithRetry("/multiquery", multiquery.String())
+	if err != nil {
+		for _, i := range pending {
+			queryErrors[i] = err
+		}
+		return queryErrors
+	}
+
+	var results []multiqueryResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		for _, i := range pending {
+			queryErrors[i] = &RequestError{Endpoint: "/multiquery", StatusCode: http.StatusOK, Err: fmt.Errorf("error decoding response body: %w", err)}
+		}
+		return queryErrors
+	}
+
+	resultsByName := map[string]json.RawMessage{}
+	for _, result := range results {
+		resultsByName[result.Name] = result.Result
+	}
+
+	for _, i := range pending {
+		result, ok := resultsByName[strconv.Itoa(i)]
+		if !ok {
+			queryErrors[i] = &RequestError{Endpoint: "/multiquery", StatusCode: http.StatusOK, Err: fmt.Errorf("no result for query %d", i)}
+			continue
+		}
+
+		if err := json.Unmarshal(result, targets[i]); err != nil {
+			queryErrors[i] = &RequestError{Endpoint: "/multiquery", StatusCode: http.StatusOK, Err: fmt.Errorf("error decoding result of query %d: %w", i, err)}
+			continue
+		}
+
+		if err := c.cache.put(path, queries[i], result); err != nil {
+			fmt.Fprintf(c.output, "error caching IGDB response: %v\n", err)
+		}
+	}
+
+	return queryErrors
+}
+
+// chunkIndexes splits the indexes into consecutive chunks of at most size indexes.
+func chunkIndexes(indexes []int, size int) [][]int {
+	chunks := [][]int{}
+	for start := 0; start < len(indexes); start += size {
+		chunks = append(chunks, indexes[start:min(start+size, len(indexes))])
+	}
+
+	return chunks
+}
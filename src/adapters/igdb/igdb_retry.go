@@ -0,0 +1,94 @@
+package igdb
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxAttempts is the number of times an IGDB request is attempted
+// before giving up, and DefaultRetryBaseDelay the backoff before the first retry.
+const (
+	DefaultMaxAttempts    = 4
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+)
+
+// maxRetryDelay caps both the exponential backoff and the wait requested by a
+// Retry-After header, so a misbehaving server cannot stall a translation.
+const maxRetryDelay = 60 * time.Second
+
+// retryPolicy decides whether and when a failed IGDB request is retried.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// newRetryPolicy creates a retry policy, falling back to the defaults for
+// values that are not set.
+func newRetryPolicy(maxAttempts int, baseDelay time.Duration) retryPolicy {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if baseDelay <= 0 {
+		baseDelay = DefaultRetryBaseDelay
+	}
+
+	return retryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay}
+}
+
+// retryable reports whether a failed request is worth retrying: network
+// errors, rate limiting and server errors are, authentication failures and
+// any other response are not.
+func (p retryPolicy) retryable(err *RequestError) bool {
+	var authErr *AuthError
+	switch {
+	case errors.As(err, &authErr):
+		return false
+	case err.StatusCode == 0:
+		return true
+	case err.StatusCode == http.StatusTooManyRequests:
+		return true
+	default:
+		return err.StatusCode >= http.StatusInternalServerError
+	}
+}
+
+// delay returns how long to wait before the next attempt. The backoff doubles
+// with every attempt and is jittered so concurrent requests do not retry in
+// lockstep; a longer Retry-After from the server takes precedence.
+func (p retryPolicy) delay(attempt int, retryAfter string) time.Duration {
+	backoff := min(p.baseDelay<<(attempt-1), maxRetryDelay)
+	backoff = backoff/2 + rand.N(backoff/2+1)
+
+	if wait, ok := parseRetryAfter(retryAfter, time.Now()); ok && wait > backoff {
+		return min(wait, maxRetryDelay)
+	}
+
+	return backoff
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
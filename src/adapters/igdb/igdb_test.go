@@ -1,11 +1,20 @@
 package igdb
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"main/src/_test/mocks"
 	"main/src/domain"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestMain(m *testing.M) {
@@ -34,7 +43,7 @@ func TestMain(m *testing.M) {
 }
 
 func TestGetGameData(t *testing.T) {
-	igdbAdapter := NewIGDBAdapter(IGDBAdapterInit{
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
 		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
 		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
 		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
@@ -44,7 +53,10 @@ func TestGetGameData(t *testing.T) {
 	gameID := []int{1068} // <-- Super Mario Bros 3 ID value in IGDB
 
 	// Execution
-	gameData := igdbAdapter.GetGameData(gameID)
+	gameData, err := igdbAdapter.GetGameData(gameID)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
 
 	fmt.Println("Game Data count:", len(gameData))
 	fmt.Printf("Game Data: %+v\n", gameData[0].Cover)
@@ -53,7 +65,20 @@ func TestGetGameData(t *testing.T) {
 		t.Errorf("Expected game name to be Super Mario Bros. 3, but got %s", gameData[0].Name)
 	}
 
-	multipleGameData := igdbAdapter.GetGameData([]int{1068, 1069})
+	expectedCompany := IGDBInvolvedCompany{ID: 4018, Company: IGDBNamedData{ID: 70, Name: "Nintendo"}, Developer: true, Publisher: true}
+	if len(gameData[0].Involved_companies) != 1 || gameData[0].Involved_companies[0] != expectedCompany {
+		t.Errorf("Expected the involved companies to be expanded, but got %+v", gameData[0].Involved_companies)
+	}
+
+	if len(gameData[0].Genres) != 1 || gameData[0].Genres[0].Name != "Platform" {
+		t.Errorf("Expected the genres to be expanded, but got %+v", gameData[0].Genres)
+	}
+
+	if len(gameData[0].Videos) != 2 || gameData[0].Videos[0].Video_id != "mR6ZfUwPgqk" {
+		t.Errorf("Expected the videos to be expanded, but got %+v", gameData[0].Videos)
+	}
+
+	multipleGameData, _ := igdbAdapter.GetGameData([]int{1068, 1069})
 
 	for _, game := range multipleGameData {
 		t.Logf("Game ID: %d, Name: %s", game.ID, game.Name)
@@ -79,7 +104,7 @@ func TestGameTitleNormalization(t *testing.T) {
 }
 
 func TestFuzzyFindIGDBGameByTitle(t *testing.T) {
-	igdbAdapter := NewIGDBAdapter(IGDBAdapterInit{
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
 		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
 		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
 		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
@@ -90,9 +115,13 @@ func TestFuzzyFindIGDBGameByTitle(t *testing.T) {
 	clzPlatform := "NES"
 
 	// Execution
-	gameID := igdbAdapter.FuzzyFindGameByTitle(title, clzPlatform)
+	gameID, err := igdbAdapter.FuzzyFindGameByTitle(title, clzPlatform)
 
 	// Assertion
+	if err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+
 	if gameID == 0 {
 		t.Errorf("Expected game ID to be found, but got 0")
 	}
@@ -103,7 +132,7 @@ func TestFuzzyFindIGDBGameByTitle(t *testing.T) {
 }
 
 func TestFuzzySearchList(t *testing.T) {
-	igdbAdapter := NewIGDBAdapter(IGDBAdapterInit{
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
 		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
 		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
 		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
@@ -127,7 +156,7 @@ func TestFuzzySearchList(t *testing.T) {
 	}
 
 	// Execution
-	fuzzySearchedGames := igdbAdapter.FuzzyFindGamesList(mockGamesList)
+	fuzzySearchedGames, lookupErrors := igdbAdapter.FuzzyFindGamesList(mockGamesList)
 
 	// Assertion
 	if len(fuzzySearchedGames) == 0 {
@@ -137,4 +166,759 @@ func TestFuzzySearchList(t *testing.T) {
 	if fuzzySearchedGames[0].IGDB_ID != 3 {
 		t.Errorf("Expected game IGDB_ID to be 3, but got %d", fuzzySearchedGames[0].IGDB_ID)
 	}
+
+	if len(lookupErrors) != len(mockGamesList) || lookupErrors[0] != nil {
+		t.Errorf("Expected no lookup error for the first game, but got %v", lookupErrors)
+	}
+}
+
+func TestGetGameDataRequestError(t *testing.T) {
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failingServer.Close()
+
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      failingServer.URL,
+		MaxAttempts:      3,
+		RetryBaseDelay:   time.Millisecond,
+	})
+
+	// Execution
+	_, err := igdbAdapter.GetGameData([]int{1068})
+
+	// Assertion
+	var requestErr *RequestError
+	if !errors.As(err, &requestErr) {
+		t.Fatalf("Expected a RequestError, but got %v", err)
+	}
+
+	if requestErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected status code 500, but got %d", requestErr.StatusCode)
+	}
+
+	if requestErr.Attempts != 3 {
+		t.Errorf("Expected the request to be attempted 3 times, but got %d", requestErr.Attempts)
+	}
+}
+
+func TestGetGameDataRetry(t *testing.T) {
+	mockIGDBServer := mocks.GetTestIGDBServer()
+	defer mockIGDBServer.Close()
+
+	tests := []struct {
+		name             string
+		failures         []int
+		expectedRequests int
+		expectedStatus   int
+	}{
+		{"recovers after rate limiting", []int{http.StatusTooManyRequests, http.StatusTooManyRequests}, 3, 0},
+		{"recovers after a server error", []int{http.StatusBadGateway}, 2, 0},
+		{"does not retry a bad request", []int{http.StatusBadRequest}, 1, http.StatusBadRequest},
+		{"gives up after the attempt budget", []int{503, 503, 503, 503, 503}, 4, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestCount := 0
+			flakyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestCount++
+				if requestCount <= len(tt.failures) {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.failures[requestCount-1])
+					return
+				}
+
+				proxyRequest, _ := http.NewRequest(r.Method, mockIGDBServer.URL+r.URL.Path, r.Body)
+				response, err := http.DefaultClient.Do(proxyRequest)
+				if err != nil {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				defer response.Body.Close()
+				io.Copy(w, response.Body)
+			}))
+			defer flakyServer.Close()
+
+			igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+				AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+				AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+				AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+				AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+				IGDBBaseUrl:      flakyServer.URL,
+				MaxAttempts:      4,
+				RetryBaseDelay:   time.Millisecond,
+			})
+
+			// Execution
+			gameData, err := igdbAdapter.GetGameData([]int{1068})
+
+			// Assertion
+			if requestCount != tt.expectedRequests {
+				t.Errorf("Expected %d requests, but got %d", tt.expectedRequests, requestCount)
+			}
+
+			if tt.expectedStatus == 0 {
+				if err != nil || len(gameData) == 0 || gameData[0].Name != "Super Mario Bros. 3" {
+					t.Errorf("Expected game data after retrying, but got %v, %v", gameData, err)
+				}
+				return
+			}
+
+			var requestErr *RequestError
+			if !errors.As(err, &requestErr) || requestErr.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected a RequestError with status %d, but got %v", tt.expectedStatus, err)
+			}
+			if requestErr.Attempts != tt.expectedRequests {
+				t.Errorf("Expected %d attempts, but got %d", tt.expectedRequests, requestErr.Attempts)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value         string
+		expectedWait  time.Duration
+		expectedValid bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"Mon, 01 Jan 2024 12:00:10 GMT", 10 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		wait, ok := parseRetryAfter(tt.value, now)
+		if wait != tt.expectedWait || ok != tt.expectedValid {
+			t.Errorf("Expected parseRetryAfter(%q) to be %v, %v, but got %v, %v", tt.value, tt.expectedWait, tt.expectedValid, wait, ok)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := newRetryPolicy(4, 100*time.Millisecond)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		backoff := 100 * time.Millisecond << (attempt - 1)
+		delay := policy.delay(attempt, "")
+		if delay < backoff/2 || delay > backoff {
+			t.Errorf("Expected the delay for attempt %d to be between %v and %v, but got %v", attempt, backoff/2, backoff, delay)
+		}
+	}
+
+	if delay := policy.delay(1, "2"); delay != 2*time.Second {
+		t.Errorf("Expected Retry-After to take precedence, but got %v", delay)
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	requestCount := 0
+	mockIGDBServer := mocks.GetTestIGDBServer()
+	defer mockIGDBServer.Close()
+	countingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		proxyRequest, _ := http.NewRequest(r.Method, mockIGDBServer.URL+r.URL.Path, r.Body)
+		response, err := http.DefaultClient.Do(proxyRequest)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer response.Body.Close()
+		io.Copy(w, response.Body)
+	}))
+	defer countingServer.Close()
+
+	cacheDir := t.TempDir()
+	newAdapter := func(refresh bool) *IGDBAdapter {
+		return mustNewIGDBAdapter(t, IGDBAdapterInit{
+			AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+			AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+			AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+			AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+			IGDBBaseUrl:      countingServer.URL,
+			CacheDir:         cacheDir,
+			RefreshCache:     refresh,
+		})
+	}
+
+	// Execution
+	igdbAdapter := newAdapter(false)
+	igdbAdapter.GetGameData([]int{1068})
+	igdbAdapter.GetGameData([]int{1068})
+	igdbAdapter.FuzzyFindGameByTitle("Super Mario Bros. 3", "NES")
+	igdbAdapter.FuzzyFindGameByTitle("Super Mario Bros. 3", "NES")
+
+	// Assertion
+	if requestCount != 2 {
+		t.Errorf("Expected 2 requests with a warm cache, but got %d", requestCount)
+	}
+
+	// a new adapter sharing the cache directory should not query IGDB again
+	gameData, err := newAdapter(false).GetGameData([]int{1068})
+	if err != nil || gameData[0].Name != "Super Mario Bros. 3" {
+		t.Errorf("Expected cached game data, but got %v, %v", gameData, err)
+	}
+	if requestCount != 2 {
+		t.Errorf("Expected cached response to be reused across adapters, but got %d requests", requestCount)
+	}
+
+	// refreshing the cache should always query IGDB
+	newAdapter(true).GetGameData([]int{1068})
+	if requestCount != 3 {
+		t.Errorf("Expected refresh to query IGDB, but got %d requests", requestCount)
+	}
+
+	// another IGDB server sharing the cache directory must not be served the responses of the first
+	otherRequestCount := 0
+	otherServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherRequestCount++
+		w.Write([]byte("[]"))
+	}))
+	defer otherServer.Close()
+
+	otherAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      otherServer.URL,
+		CacheDir:         cacheDir,
+	})
+	gameData, err = otherAdapter.GetGameData([]int{1068})
+	if err != nil || len(gameData) != 0 || otherRequestCount != 1 {
+		t.Errorf("Expected the other server to be queried, but got %v, %v after %d requests", gameData, err, otherRequestCount)
+	}
+}
+
+func TestRankCandidates(t *testing.T) {
+	searchResults := []igdbFuzzySearchGameData{
+		{ID: 1, Name: "Super Mario Bros. 3+", Platforms: []int{18}},
+		{ID: 2, Name: "Tokobot", Platforms: []int{18}},
+		{ID: 3, Name: "Super Mario Bros. 3", Platforms: []int{18}, First_release_date: 593568000},
+		{ID: 4, Name: "Super Mario Bros. 3", Platforms: []int{5}, First_release_date: 1180656000},
+	}
+	game := domain.Game{Title: "Super Mario Bros. 3", Platform: "NES", ReleaseDate: domain.NewDate(1988, 10, 23)}
+
+	// Execution
+	candidates := rankCandidates(game, searchResults)
+
+	// Assertion
+	expectedOrder := []int{3, 1, 4, 2}
+	for i, candidate := range candidates {
+		if candidate.ID != expectedOrder[i] {
+			t.Errorf("Expected candidate %d to be ID %d, but got %d (%+v)", i, expectedOrder[i], candidate.ID, candidates)
+		}
+	}
+
+	if candidates[0].Confidence != 1 {
+		t.Errorf("Expected exact match to have confidence 1, but got %f", candidates[0].Confidence)
+	}
+
+	if candidates[0].ReleaseYear != 1988 {
+		t.Errorf("Expected release year 1988, but got %d", candidates[0].ReleaseYear)
+	}
+}
+
+func TestFuzzyFindLowConfidence(t *testing.T) {
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+	})
+
+	// Execution
+	candidates, rankErr := igdbAdapter.RankGameCandidates(domain.Game{Title: "The Legend of Zelda", Platform: "NES"})
+	gameID, err := igdbAdapter.FuzzyFindGameByTitle("The Legend of Zelda", "NES")
+
+	// Assertion
+	if gameID != 0 {
+		t.Errorf("Expected no game ID for an unrelated search result, but got %d", gameID)
+	}
+
+	var lowConfidenceErr *LowConfidenceError
+	if !errors.As(err, &lowConfidenceErr) || !errors.Is(err, ErrNoMatch) {
+		t.Fatalf("Expected a LowConfidenceError, but got %v", err)
+	}
+
+	if len(lowConfidenceErr.Candidates) == 0 || lowConfidenceErr.Candidates[0].Confidence >= DefaultMatchThreshold {
+		t.Errorf("Expected ranked candidates below the threshold, but got %+v", lowConfidenceErr.Candidates)
+	}
+
+	if rankErr != nil || !reflect.DeepEqual(candidates, lowConfidenceErr.Candidates) {
+		t.Errorf("Expected RankGameCandidates to return the ranked candidates, but got %+v, %v", candidates, rankErr)
+	}
+
+	// a zero threshold is kept rather than replaced by the default, accepting the best candidate
+	threshold := 0.0
+	acceptingAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+		MatchThreshold:   &threshold,
+	})
+	gameID, err = acceptingAdapter.FuzzyFindGameByTitle("The Legend of Zelda", "NES")
+	if err != nil || gameID != candidates[0].ID {
+		t.Errorf("Expected a zero threshold to accept IGDB_ID %d, but got %d, %v", candidates[0].ID, gameID, err)
+	}
+}
+
+func TestFuzzySearchListOverrides(t *testing.T) {
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+		Overrides: domain.MatchOverrides{
+			{CLZ_ID: 1, IGDB_ID: 1068},
+			{Title: "tokobot", Platform: "NES", Skip: true},
+		},
+	})
+
+	mockGamesList := []domain.Game{
+		{CLZ_ID: 1, Title: "Super Mario Bros. 3", Platform: "NES"},
+		{CLZ_ID: 2, Title: "Tokobot", Platform: "NES"},
+	}
+
+	// Execution
+	fuzzySearchedGames, lookupErrors := igdbAdapter.FuzzyFindGamesList(mockGamesList)
+
+	// Assertion
+	if fuzzySearchedGames[0].IGDB_ID != 1068 {
+		t.Errorf("Expected overridden IGDB_ID to be 1068, but got %d", fuzzySearchedGames[0].IGDB_ID)
+	}
+
+	if fuzzySearchedGames[1].IGDB_ID != 0 || !errors.Is(lookupErrors[1], ErrEnrichmentSkipped) {
+		t.Errorf("Expected skipped game to be unmatched, but got %d, %v", fuzzySearchedGames[1].IGDB_ID, lookupErrors[1])
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	interval := 20 * time.Millisecond
+	limiter := newRateLimiter(interval, 2)
+
+	inFlight, maxInFlight := 0, 0
+	var mu sync.Mutex
+	start := time.Now()
+
+	// Execution
+	runConcurrently(6, 6, func(i int) {
+		release := limiter.acquire()
+		defer release()
+
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	// Assertion
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, but got %d", maxInFlight)
+	}
+
+	// the first request starts immediately, the other five wait an interval each
+	if elapsed := time.Since(start); elapsed < 5*interval {
+		t.Errorf("Expected requests to be spaced by %v, but 6 requests took %v", interval, elapsed)
+	}
+}
+
+func TestGetGameDataBatches(t *testing.T) {
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+		MaxConcurrency:   2,
+	})
+
+	// Execution
+	results, batchErrors := igdbAdapter.GetGameDataBatches([][]int{{1068}, {1068}, {1068}})
+
+	// Assertion
+	if len(results) != 3 || len(batchErrors) != 3 {
+		t.Fatalf("Expected results and errors for 3 batches, but got %d and %d", len(results), len(batchErrors))
+	}
+
+	for i := range results {
+		if batchErrors[i] != nil {
+			t.Errorf("Expected no error for batch %d, but got %v", i, batchErrors[i])
+		}
+		if len(results[i]) == 0 || results[i][0].Name != "Super Mario Bros. 3" {
+			t.Errorf("Expected batch %d to contain Super Mario Bros. 3, but got %v", i, results[i])
+		}
+	}
+}
+
+func mustNewIGDBAdapter(t *testing.T, init IGDBAdapterInit) *IGDBAdapter {
+	t.Helper()
+
+	igdbAdapter, err := NewIGDBAdapter(init)
+	if err != nil {
+		t.Fatalf("Expected no error creating the IGDB adapter, but got %v", err)
+	}
+
+	return igdbAdapter
+}
+
+// newCountingAuthServer returns a Twitch auth server issuing token-1, token-2,
+// ... and a function returning the number of tokens issued.
+func newCountingAuthServer(expiresIn int) (*httptest.Server, func() int) {
+	var mu sync.Mutex
+	issued := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		issued++
+		token := fmt.Sprintf("token-%d", issued)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": token,
+			"expires_in":   expiresIn,
+			"token_type":   "bearer",
+		})
+	}))
+
+	return server, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return issued
+	}
+}
+
+func TestTokenCaching(t *testing.T) {
+	authServer, issued := newCountingAuthServer(3600)
+	defer authServer.Close()
+
+	cacheDir := t.TempDir()
+	init := IGDBAdapterInit{
+		AuthBaseUrl:      authServer.URL,
+		AuthUrlPath:      "/oauth2/token",
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+		CacheDir:         cacheDir,
+	}
+
+	// Execution
+	mustNewIGDBAdapter(t, init)
+	mustNewIGDBAdapter(t, init)
+
+	// Assertion
+	if issued() != 1 {
+		t.Errorf("Expected the persisted token to be reused, but %d tokens were issued", issued())
+	}
+
+	tokenFile := filepath.Join(cacheDir, tokenFileName)
+	info, err := os.Stat(tokenFile)
+	if err != nil {
+		t.Fatalf("Expected the token to be persisted, but got %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the token file to be private, but got mode %v", info.Mode().Perm())
+	}
+
+	// an expired token should be replaced
+	expired, _ := json.Marshal(storedToken{ClientID: init.AuthClientId, AccessToken: "token-1", ExpiresAt: time.Now().Add(-time.Hour)})
+	os.WriteFile(tokenFile, expired, 0600)
+	mustNewIGDBAdapter(t, init)
+
+	if issued() != 2 {
+		t.Errorf("Expected the expired token to be refreshed, but %d tokens were issued", issued())
+	}
+
+	// a token issued to another client should not be reused
+	init.AuthClientId = "other_client_id"
+	mustNewIGDBAdapter(t, init)
+
+	if issued() != 3 {
+		t.Errorf("Expected a new token for another client, but %d tokens were issued", issued())
+	}
+}
+
+func TestTokenRefreshOnUnauthorized(t *testing.T) {
+	authServer, issued := newCountingAuthServer(3600)
+	defer authServer.Close()
+
+	mockIGDBServer := mocks.GetTestIGDBServer()
+	defer mockIGDBServer.Close()
+
+	// only accept the second token, as if the first one had been revoked
+	igdbServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		proxyRequest, _ := http.NewRequest(r.Method, mockIGDBServer.URL+r.URL.Path, r.Body)
+		response, err := http.DefaultClient.Do(proxyRequest)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer response.Body.Close()
+		io.Copy(w, response.Body)
+	}))
+	defer igdbServer.Close()
+
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      authServer.URL,
+		AuthUrlPath:      "/oauth2/token",
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      igdbServer.URL,
+	})
+
+	// Execution
+	gameData, err := igdbAdapter.GetGameData([]int{1068})
+
+	// Assertion
+	if err != nil || len(gameData) == 0 || gameData[0].Name != "Super Mario Bros. 3" {
+		t.Errorf("Expected game data after refreshing the token, but got %v, %v", gameData, err)
+	}
+
+	if issued() != 2 {
+		t.Errorf("Expected the rejected token to be refreshed once, but %d tokens were issued", issued())
+	}
+}
+
+func TestAuthFailure(t *testing.T) {
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer authServer.Close()
+
+	// Execution
+	igdbAdapter, err := NewIGDBAdapter(IGDBAdapterInit{
+		AuthBaseUrl:      authServer.URL,
+		AuthUrlPath:      "/oauth2/token",
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: "wrong_secret",
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+	})
+
+	// Assertion
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("Expected an AuthError, but got %v", err)
+	}
+
+	if authErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, but got %d", authErr.StatusCode)
+	}
+
+	if igdbAdapter != nil {
+		t.Errorf("Expected no adapter after an authentication failure, but got %v", igdbAdapter)
+	}
+}
+
+func TestIsolatedClients(t *testing.T) {
+	mockIGDBServer := mocks.GetTestIGDBServer()
+	defer mockIGDBServer.Close()
+
+	// newRecordingServer proxies the mock IGDB server and records the client IDs it was called with
+	newRecordingServer := func(clientIDs *[]string, mu *sync.Mutex) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			*clientIDs = append(*clientIDs, r.Header.Get("Client-ID"))
+			mu.Unlock()
+
+			proxyRequest, _ := http.NewRequest(r.Method, mockIGDBServer.URL+r.URL.Path, r.Body)
+			response, err := http.DefaultClient.Do(proxyRequest)
+			if err != nil {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			defer response.Body.Close()
+			io.Copy(w, response.Body)
+		}))
+	}
+
+	var mu sync.Mutex
+	var clientIDsA, clientIDsB []string
+	serverA := newRecordingServer(&clientIDsA, &mu)
+	defer serverA.Close()
+	serverB := newRecordingServer(&clientIDsB, &mu)
+	defer serverB.Close()
+
+	newClient := func(clientID string, baseUrl string, threshold float64) *Client {
+		client, err := NewClient(IGDBAdapterInit{
+			AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+			AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+			AuthClientId:     clientID,
+			AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+			IGDBBaseUrl:      baseUrl,
+			MatchThreshold:   &threshold,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error creating the client, but got %v", err)
+		}
+		return client
+	}
+
+	clientA := newClient("client_a", serverA.URL, 0)
+	clientB := newClient("client_b", serverB.URL, 0.99)
+
+	// Execution
+	var idA, idB int
+	var errA, errB error
+	runConcurrently(2, 2, func(i int) {
+		if i == 0 {
+			idA, errA = clientA.FuzzyFindGameByTitle("Super Mario Bros. 3", "NES")
+		} else {
+			idB, errB = clientB.FuzzyFindGameByTitle("Super Mario Bros. 3", "NES")
+		}
+	})
+
+	// Assertion
+	if errA != nil || idA != 3 {
+		t.Errorf("Expected client A to match IGDB_ID 3, but got %d, %v", idA, errA)
+	}
+
+	var lowConfidenceErr *LowConfidenceError
+	if !errors.As(errB, &lowConfidenceErr) || idB != 0 {
+		t.Errorf("Expected client B's stricter threshold to reject the match, but got %d, %v", idB, errB)
+	}
+
+	if !reflect.DeepEqual(clientIDsA, []string{"client_a"}) {
+		t.Errorf("Expected server A to only be queried by client A, but got %v", clientIDsA)
+	}
+
+	if !reflect.DeepEqual(clientIDsB, []string{"client_b"}) {
+		t.Errorf("Expected server B to only be queried by client B, but got %v", clientIDsB)
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	expected := `search "the \"quoted\" c:\\game"; fields id, name, platforms, first_release_date;`
+	if actual := searchQuery(`the "quoted" c:\game`); actual != expected {
+		t.Errorf("Expected %s, but got %s", expected, actual)
+	}
+}
+
+func TestFuzzyFindGamesListQuotedTitle(t *testing.T) {
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
+	})
+
+	// Execution
+	matchedGames, lookupErrors := igdbAdapter.FuzzyFindGamesList([]domain.Game{
+		{Title: `Super Mario Bros. 3 "Player's Choice"`, Platform: "NES"},
+		{Title: "Super Mario Bros. 3", Platform: "NES"},
+	})
+
+	// Assertion
+	var requestErr *RequestError
+	if errors.As(lookupErrors[0], &requestErr) {
+		t.Errorf("Expected the quoted title to be searched, but got %v", lookupErrors[0])
+	}
+
+	// the other searches of the multiquery request are unaffected by the quoted title
+	if lookupErrors[1] != nil || matchedGames[1].IGDB_ID != 3 {
+		t.Errorf("Expected Super Mario Bros. 3 to match IGDB_ID 3, but got %d, %v", matchedGames[1].IGDB_ID, lookupErrors[1])
+	}
+}
+
+func TestMultiquery(t *testing.T) {
+	mockIGDBServer := mocks.GetTestIGDBServer()
+	defer mockIGDBServer.Close()
+
+	var mu sync.Mutex
+	requestPaths := []string{}
+	countingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestPaths = append(requestPaths, r.URL.Path)
+		mu.Unlock()
+
+		proxyRequest, _ := http.NewRequest(r.Method, mockIGDBServer.URL+r.URL.Path, r.Body)
+		response, err := http.DefaultClient.Do(proxyRequest)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer response.Body.Close()
+		io.Copy(w, response.Body)
+	}))
+	defer countingServer.Close()
+
+	cacheDir := t.TempDir()
+	igdbAdapter := mustNewIGDBAdapter(t, IGDBAdapterInit{
+		AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
+		AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
+		AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
+		AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
+		IGDBBaseUrl:      countingServer.URL,
+		CacheDir:         cacheDir,
+	})
+
+	gamesList := []domain.Game{}
+	for i := 0; i < 12; i++ {
+		gamesList = append(gamesList, domain.Game{Title: fmt.Sprintf("Super Mario Bros. %d", i), Platform: "NES"})
+	}
+
+	// Execution
+	matchedGames, lookupErrors := igdbAdapter.FuzzyFindGamesList(gamesList)
+
+	// Assertion
+	if !reflect.DeepEqual(requestPaths, []string{"/multiquery", "/multiquery"}) {
+		t.Errorf("Expected 12 searches to take 2 multiquery requests, but got %v", requestPaths)
+	}
+
+	for i, game := range matchedGames {
+		if lookupErrors[i] != nil || game.IGDB_ID == 0 {
+			t.Errorf("Expected %s to be matched, but got %d, %v", game.Title, game.IGDB_ID, lookupErrors[i])
+		}
+	}
+
+	// searches already cached should not be sent again, even one at a time
+	requestPaths = nil
+	igdbAdapter.FuzzyFindGamesList(gamesList)
+	igdbAdapter.FuzzyFindGameByTitle("Super Mario Bros. 3", "NES")
+
+	if len(requestPaths) != 0 {
+		t.Errorf("Expected cached searches to be reused, but got requests %v", requestPaths)
+	}
+
+	// detail fetches for several batches should also be packed together
+	requestPaths = nil
+	batches := [][]int{}
+	for i := 0; i < 12; i++ {
+		batches = append(batches, []int{1068, 2000 + i})
+	}
+	results, batchErrors := igdbAdapter.GetGameDataBatches(batches)
+
+	if !reflect.DeepEqual(requestPaths, []string{"/multiquery", "/multiquery"}) {
+		t.Errorf("Expected 12 batches to take 2 multiquery requests, but got %v", requestPaths)
+	}
+
+	for i := range batches {
+		if batchErrors[i] != nil || len(results[i]) == 0 {
+			t.Errorf("Expected game data for batch %d, but got %v, %v", i, results[i], batchErrors[i])
+		}
+	}
 }
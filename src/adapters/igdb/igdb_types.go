@@ -1,14 +1,20 @@
 package igdb
 
 import (
+	"io"
 	"main/src/domain"
+	"net/http"
+	"time"
 )
 
-// IGDBAdapter is an adapter for the IGDB API.
+// IGDBAdapter is an adapter for the IGDB API, backed by a Client created by NewIGDBAdapter.
 //
 // Fields:
 //   - GetGameData: A function that retrieves game data from the IGDB API.
 //   - FuzzyFindGameByTitle: A function that searches for a game by title and platform.
+//   - FuzzyFindGamesList: A function that matches every game in a list to an IGDB ID.
+//   - RankGameCandidates: A function that returns scored IGDB candidates for a game.
+//   - GetGameDataBatches: A function that retrieves game data for several batches of IDs concurrently.
 type IGDBAdapter struct {
 	// GetGameData takes a unique game ID value and returns the requested game details.
 	//
@@ -17,7 +23,8 @@ type IGDBAdapter struct {
 	//
 	// Returns:
 	//   - An IGDBGameData instance representing the requested game.
-	GetGameData func([]int) []IGDBGameData
+	//   - error: A *RequestError if the IGDB request fails, otherwise nil.
+	GetGameData func([]int) ([]IGDBGameData, error)
 
 	// FuzzyFindGameByTitle takes a game title and platform name, and returns the ID of the game that matches the title and platform.
 	//
@@ -26,17 +33,46 @@ type IGDBAdapter struct {
 	//   - clzPlatform: The platform name string.
 	//
 	// Returns:
-	//   - The ID int value of the game that matches the title and platform.
-	FuzzyFindGameByTitle func(string, string) int
+	//   - The ID int value of the best scored game for the title and platform.
+	//   - error: ErrNoMatch if the search returned no games, a *LowConfidenceError if no game reached
+	//     the match threshold, or a *RequestError if the IGDB request fails.
+	FuzzyFindGameByTitle func(string, string) (int, error)
 
 	// FuzzyFindGamesList takes a game title and returns a list of games that match the title.
+	// Games with a match override are pinned to the overridden IGDB ID, or skipped, without searching.
+	// The searches are packed into multiquery requests of up to MultiqueryLimit searches.
 	//
 	// Fields:
 	//   - gamesList: The game collection list
 	//
 	// Returns:
 	//   - The games list with entires updated with IGDB ID values.
-	FuzzyFindGamesList func([]domain.Game) []domain.Game
+	//   - A slice of errors aligned with the games list, holding the lookup error for each game left unmatched,
+	//     ErrEnrichmentSkipped for games skipped by an override.
+	FuzzyFindGamesList func([]domain.Game) ([]domain.Game, []error)
+
+	// RankGameCandidates searches IGDB for a game and scores every result against its title,
+	// platform and release date.
+	//
+	// Fields:
+	//   - game: The game to find candidates for.
+	//
+	// Returns:
+	//   - The candidates ordered by descending confidence.
+	//   - error: A *RequestError if the IGDB request fails.
+	RankGameCandidates func(domain.Game) ([]MatchCandidate, error)
+
+	// GetGameDataBatches retrieves the game details for several batches of game IDs concurrently,
+	// within the adapter's rate limit. The batches are packed into multiquery requests of up to
+	// MultiqueryLimit batches, each batch holding at most MaxQueryResults IDs.
+	//
+	// Fields:
+	//   - batches: The batches of game ID int values.
+	//
+	// Returns:
+	//   - The IGDBGameData retrieved for each batch, in batch order.
+	//   - A slice of errors aligned with the batches, holding a *RequestError for each failed batch.
+	GetGameDataBatches func([][]int) ([][]IGDBGameData, []error)
 }
 
 // IGDBAdapterInit contains the initialization parameters for the IGDBAdapter.
@@ -47,12 +83,35 @@ type IGDBAdapter struct {
 //   - AuthClientId: The client ID for authentication.
 //   - AuthClientSecret: The client secret for authentication.
 //   - IGDBBaseUrl: The base URL for the IGDB API.
+//   - CacheDir: The directory IGDB responses and the access token are cached in, caching is disabled when empty.
+//   - CacheTTL: The time cached responses are reused for, DefaultCacheTTL when zero.
+//   - RefreshCache: Whether to ignore cached responses and re-query IGDB, refreshing the cache.
+//   - MatchThreshold: The minimum confidence (0-1) for a fuzzy match to be accepted, DefaultMatchThreshold when nil.
+//     Zero accepts the best candidate however low its confidence.
+//   - Overrides: Manual match decisions consulted before searching for a game.
+//   - RateLimit: The minimum interval between IGDB requests, zero disables rate limiting.
+//   - MaxConcurrency: The maximum number of IGDB requests in flight, DefaultMaxConcurrency when zero.
+//   - HTTPClient: The HTTP client to make requests with, a new client when nil.
+//   - MaxAttempts: The number of times a failing IGDB request is attempted, DefaultMaxAttempts when zero.
+//   - RetryBaseDelay: The backoff before the first retry, DefaultRetryBaseDelay when zero.
+//   - Output: The writer progress and failed lookups are printed to, os.Stdout when nil.
 type IGDBAdapterInit struct {
 	AuthBaseUrl      string
 	AuthUrlPath      string
 	AuthClientId     string
 	AuthClientSecret string
 	IGDBBaseUrl      string
+	CacheDir         string
+	CacheTTL         time.Duration
+	RefreshCache     bool
+	MatchThreshold   *float64
+	Overrides        domain.MatchOverrides
+	RateLimit        time.Duration
+	MaxConcurrency   int
+	MaxAttempts      int
+	RetryBaseDelay   time.Duration
+	HTTPClient       *http.Client
+	Output           io.Writer
 }
 
 // IGDBPlatformData represents the data structure for a platform retrieved from the IGDB API.
@@ -65,29 +124,77 @@ type IGDBPlatformData struct {
 	Name string `json:"name"`
 }
 
+// IGDBCover represents an image retrieved from the IGDB API, used for covers, artworks and screenshots.
+//
+// Fields:
+//   - ID: The unique ID value of the image.
+//   - URL: The URL of the image.
+//   - Width: The width of the image in pixels.
 type IGDBCover struct {
 	ID    int    `json:"id"`
 	URL   string `json:"url"`
 	Width int    `json:"width"`
 }
 
+// IGDBNamedData represents an IGDB entity referenced by a game that has a name, such as a genre,
+// franchise or company.
+//
+// Fields:
+//   - ID: The unique ID value of the entity.
+//   - Name: The name of the entity.
+type IGDBNamedData struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// IGDBInvolvedCompany represents a company involved in making a game and its roles.
+//
+// Fields:
+//   - ID: The unique ID value of the involvement.
+//   - Company: The company.
+//   - Developer: Whether the company developed the game.
+//   - Publisher: Whether the company published the game.
+type IGDBInvolvedCompany struct {
+	ID        int           `json:"id"`
+	Company   IGDBNamedData `json:"company"`
+	Developer bool          `json:"developer"`
+	Publisher bool          `json:"publisher"`
+}
+
+// IGDBVideo represents a video of a game, hosted on YouTube.
+//
+// Fields:
+//   - ID: The unique ID value of the video.
+//   - Name: The name of the video.
+//   - Video_id: The YouTube video ID.
+type IGDBVideo struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Video_id string `json:"video_id"`
+}
+
 // IGDBGameData represents the data structure for a game retrieved from the IGDB API.
 //
 // Fields:
 //   - ID: The unique ID value of the game.
 //   - Name: The name of the game.
+//   - Artworks, Franchises, Genres, Involved_companies, Screenshots and Videos: The referenced entities,
+//     expanded by the game data query.
 type IGDBGameData struct {
-	Artworks           []int              `json:"artworks"`
-	Cover              IGDBCover          `json:"cover"`
-	First_release_date int                `json:"first_release_date"`
-	Franchise          int                `json:"franchise"`
-	Game_status        int                `json:"game_status"`
-	Game_type          int                `json:"game_type"`
-	Genres             []int              `json:"genres"`
-	ID                 int                `json:"id"`
-	Name               string             `json:"name"`
-	Platforms          []IGDBPlatformData `json:"platforms"`
-	Storyline          string             `json:"storyline"`
-	Summary            string             `json:"summary"`
-	Videos             []int              `json:"videos"`
+	Artworks           []IGDBCover           `json:"artworks"`
+	Cover              IGDBCover             `json:"cover"`
+	First_release_date int                   `json:"first_release_date"`
+	Franchise          int                   `json:"franchise"`
+	Franchises         []IGDBNamedData       `json:"franchises"`
+	Game_status        int                   `json:"game_status"`
+	Game_type          int                   `json:"game_type"`
+	Genres             []IGDBNamedData       `json:"genres"`
+	ID                 int                   `json:"id"`
+	Involved_companies []IGDBInvolvedCompany `json:"involved_companies"`
+	Name               string                `json:"name"`
+	Platforms          []IGDBPlatformData    `json:"platforms"`
+	Screenshots        []IGDBCover           `json:"screenshots"`
+	Storyline          string                `json:"storyline"`
+	Summary            string                `json:"summary"`
+	Videos             []IGDBVideo           `json:"videos"`
 }
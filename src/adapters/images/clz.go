@@ -0,0 +1,161 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"main/src/adapters/write"
+	"main/src/domain"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// errUnmappedPath is returned for a CLZ image path outside every mapped directory.
+var errUnmappedPath = errors.New("no directory mapping for path")
+
+// ImportError describes a CLZ image that could not be imported.
+//
+// Fields:
+//   - Path: The path of the image as recorded by CLZ.
+//   - Err: The underlying error.
+type ImportError struct {
+	Path string
+	Err  error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("importing CLZ image %s failed: %v", e.Path, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// PathMapping maps a directory on the Windows machine the CLZ collection was
+// exported from to the local directory holding the same files.
+//
+// Fields:
+//   - WindowsDir: The Windows directory as recorded by CLZ, e.g. C:\Users\me\Documents\Game Collector.
+//   - LocalDir: The local directory the Windows directory's files are found in.
+type PathMapping struct {
+	WindowsDir string
+	LocalDir   string
+}
+
+// ParsePathMapping parses a path mapping given as WINDOWS_DIR=LOCAL_DIR.
+func ParsePathMapping(value string) (PathMapping, error) {
+	windowsDir, localDir, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(windowsDir) == "" || strings.TrimSpace(localDir) == "" {
+		return PathMapping{}, fmt.Errorf("invalid path mapping %q, expected WINDOWS_DIR=LOCAL_DIR", value)
+	}
+
+	return PathMapping{WindowsDir: strings.TrimSpace(windowsDir), LocalDir: strings.TrimSpace(localDir)}, nil
+}
+
+// Resolve returns the local path of a Windows path within the mapped
+// directory. Windows paths are compared case-insensitively.
+func (m PathMapping) Resolve(windowsPath string) (string, bool) {
+	base := strings.TrimRight(strings.ReplaceAll(m.WindowsDir, "/", `\`), `\`)
+	normalized := strings.ReplaceAll(windowsPath, "/", `\`)
+
+	if len(normalized) <= len(base) || !strings.EqualFold(normalized[:len(base)], base) || normalized[len(base)] != '\\' {
+		return "", false
+	}
+
+	segments := strings.Split(strings.Trim(normalized[len(base):], `\`), `\`)
+	return filepath.Join(append([]string{m.LocalDir}, segments...)...), true
+}
+
+// CLZImporter imports the images CLZ stores locally for games into a Store.
+type CLZImporter struct {
+	store    *Store
+	mappings []PathMapping
+}
+
+// NewCLZImporter creates an importer locating CLZ images through the path
+// mappings, the first matching mapping wins.
+func NewCLZImporter(store *Store, mappings []PathMapping) *CLZImporter {
+	return &CLZImporter{store: store, mappings: mappings}
+}
+
+// ImportGame copies the front cover, back cover, backdrop and thumbnail CLZ
+// recorded for the game into the store and records the copies in
+// Game.CLZImageFiles. The front cover is also used as the game's cover when
+// there is no IGDB cover. A nil importer imports nothing.
+//
+// Parameters:
+//   - game: The game whose CLZ images to import.
+//
+// Returns:
+//   - error: The joined *ImportError of every image that could not be imported, otherwise nil.
+func (i *CLZImporter) ImportGame(game *domain.Game) error {
+	if i == nil {
+		return nil
+	}
+
+	var errs []error
+	importImage := func(windowsPath string, localPath *string) {
+		if windowsPath == "" {
+			return
+		}
+
+		imported, err := i.importImage(windowsPath)
+		if err != nil {
+			errs = append(errs, &ImportError{Path: windowsPath, Err: err})
+			return
+		}
+		*localPath = imported
+	}
+
+	importImage(game.CLZImages.FrontCover, &game.CLZImageFiles.FrontCover)
+	importImage(game.CLZImages.BackCover, &game.CLZImageFiles.BackCover)
+	importImage(game.CLZImages.Backdrop, &game.CLZImageFiles.Backdrop)
+	importImage(game.CLZImages.Thumbnail, &game.CLZImageFiles.Thumbnail)
+
+	if game.Cover.URL == "" && game.Cover.LocalPath == "" {
+		game.Cover.LocalPath = game.CLZImageFiles.FrontCover
+	}
+
+	return errors.Join(errs...)
+}
+
+func (i *CLZImporter) importImage(windowsPath string) (string, error) {
+	for _, mapping := range i.mappings {
+		if localPath, ok := mapping.Resolve(windowsPath); ok {
+			return i.store.Import(localPath)
+		}
+	}
+
+	return "", errUnmappedPath
+}
+
+// Import copies a local image file into the store, named after the SHA-256 of
+// its content with a normalized extension, and returns the path of the copy.
+//
+// Parameters:
+//   - sourcePath: The path of the image file to import.
+//
+// Returns:
+//   - The path of the image file in the store.
+//   - error: An error if the file cannot be read or copied, otherwise nil.
+func (s *Store) Import(sourcePath string) (string, error) {
+	data, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	localPath := filepath.Join(s.dir, hex.EncodeToString(sum[:])+normalizeExtension(filepath.Ext(sourcePath)))
+
+	if _, err := os.Stat(localPath); err == nil {
+		return localPath, nil
+	}
+
+	if err := write.WriteFile(data, localPath); err != nil {
+		return "", err
+	}
+
+	return localPath, nil
+}
@@ -0,0 +1,133 @@
+package images
+
+import (
+	"errors"
+	"io/fs"
+	"main/src/domain"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePathMapping(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected PathMapping
+		err      bool
+	}{
+		{`C:\Users\joe_c\Documents\Game Collector=/mnt/clz`, PathMapping{WindowsDir: `C:\Users\joe_c\Documents\Game Collector`, LocalDir: "/mnt/clz"}, false},
+		{` C:\CLZ = clz `, PathMapping{WindowsDir: `C:\CLZ`, LocalDir: "clz"}, false},
+		{`C:\CLZ`, PathMapping{}, true},
+		{`=clz`, PathMapping{}, true},
+		{`C:\CLZ=`, PathMapping{}, true},
+	}
+
+	for _, tt := range tests {
+		actual, err := ParsePathMapping(tt.value)
+		if (err != nil) != tt.err {
+			t.Errorf("Expected error for %q to be %v, but got %v", tt.value, tt.err, err)
+		}
+		if actual != tt.expected {
+			t.Errorf("Expected mapping for %q to be %#v, but got %#v", tt.value, tt.expected, actual)
+		}
+	}
+}
+
+func TestPathMappingResolve(t *testing.T) {
+	mapping := PathMapping{WindowsDir: `C:\Users\joe_c\Documents\Game Collector\`, LocalDir: "clz"}
+
+	tests := []struct {
+		windowsPath string
+		expected    string
+		ok          bool
+	}{
+		{`C:\Users\joe_c\Documents\Game Collector\Images\Game_1_f.jpg`, filepath.Join("clz", "Images", "Game_1_f.jpg"), true},
+		{`c:\users\JOE_C\Documents\game collector\Images\Game_1_f.jpg`, filepath.Join("clz", "Images", "Game_1_f.jpg"), true},
+		{`C:/Users/joe_c/Documents/Game Collector/Images/Game_1_b.jpg`, filepath.Join("clz", "Images", "Game_1_b.jpg"), true},
+		{`C:\Users\joe_c\Documents\Game Collector 2\Images\Game_1_f.jpg`, "", false},
+		{`D:\Images\Game_1_f.jpg`, "", false},
+	}
+
+	for _, tt := range tests {
+		actual, ok := mapping.Resolve(tt.windowsPath)
+		if ok != tt.ok || actual != tt.expected {
+			t.Errorf("Expected %q to resolve to %q (%v), but got %q (%v)", tt.windowsPath, tt.expected, tt.ok, actual, ok)
+		}
+	}
+}
+
+func TestImportGame(t *testing.T) {
+	clzDir := filepath.Join(t.TempDir(), "clz")
+	if err := os.MkdirAll(filepath.Join(clzDir, "Images"), 0755); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	os.WriteFile(filepath.Join(clzDir, "Images", "Game_1_f.JPEG"), []byte("front cover"), 0644)
+	os.WriteFile(filepath.Join(clzDir, "Images", "Game_1_b.png"), []byte("back cover"), 0644)
+
+	assetsDir := filepath.Join(t.TempDir(), "assets")
+	store, err := NewStore(assetsDir, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	importer := NewCLZImporter(store, []PathMapping{
+		{WindowsDir: `C:\Users\joe_c\Documents\Game Collector`, LocalDir: clzDir},
+	})
+
+	game := domain.Game{
+		CLZImages: domain.CLZImages{
+			FrontCover: `C:\Users\joe_c\Documents\Game Collector\Images\Game_1_f.JPEG`,
+			BackCover:  `C:\Users\joe_c\Documents\Game Collector\Images\Game_1_b.png`,
+			Thumbnail:  `C:\Users\joe_c\Documents\Game Collector\Thumbs\Game_1.jpg`,
+			Backdrop:   `D:\Backdrops\Game_1.jpg`,
+		},
+	}
+
+	err = importer.ImportGame(&game)
+
+	var importErr *ImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("Expected an *ImportError, but got %v", err)
+	}
+	if !errors.Is(err, fs.ErrNotExist) || !errors.Is(err, errUnmappedPath) {
+		t.Errorf("Expected the missing thumbnail and unmapped backdrop to be reported, but got %v", err)
+	}
+
+	front := game.CLZImageFiles.FrontCover
+	if filepath.Dir(front) != assetsDir || !strings.HasSuffix(front, ".jpg") {
+		t.Errorf("Expected the front cover to be imported into %s with a .jpg extension, but got %q", assetsDir, front)
+	}
+	if data, err := os.ReadFile(front); err != nil || string(data) != "front cover" {
+		t.Errorf("Expected the imported front cover to hold the scan, but got %q (%v)", data, err)
+	}
+	if !strings.HasSuffix(game.CLZImageFiles.BackCover, ".png") {
+		t.Errorf("Expected the back cover to be imported with a .png extension, but got %q", game.CLZImageFiles.BackCover)
+	}
+	if game.CLZImageFiles.Thumbnail != "" || game.CLZImageFiles.Backdrop != "" {
+		t.Errorf("Expected images that could not be imported to be left empty, but got %#v", game.CLZImageFiles)
+	}
+	if game.Cover.LocalPath != front {
+		t.Errorf("Expected the front cover to be used as the cover without an IGDB cover, but got %q", game.Cover.LocalPath)
+	}
+
+	// an IGDB cover is kept, and an image imported again is stored once
+	enriched := domain.Game{
+		Cover:     domain.Cover{URL: "//images.igdb.com/igdb/image/upload/t_cover_big/co1j8f.jpg"},
+		CLZImages: domain.CLZImages{FrontCover: game.CLZImages.FrontCover},
+	}
+	if err := importer.ImportGame(&enriched); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if enriched.Cover.LocalPath != "" {
+		t.Errorf("Expected the IGDB cover to be kept, but got local path %q", enriched.Cover.LocalPath)
+	}
+	if enriched.CLZImageFiles.FrontCover != front {
+		t.Errorf("Expected the same scan to be stored once at %q, but got %q", front, enriched.CLZImageFiles.FrontCover)
+	}
+
+	var disabled *CLZImporter
+	if err := disabled.ImportGame(&game); err != nil {
+		t.Errorf("Expected a nil importer to import nothing, but got %v", err)
+	}
+}
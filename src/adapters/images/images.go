@@ -0,0 +1,279 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"main/src/adapters/write"
+	"main/src/domain"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultSize is the IGDB image size preset images are downloaded in when no
+// size is configured.
+const DefaultSize = "cover_big"
+
+// Sizes are the IGDB image size presets, see https://api-docs.igdb.com/#images.
+var Sizes = []string{
+	"cover_small",
+	"cover_big",
+	"screenshot_med",
+	"screenshot_big",
+	"screenshot_huge",
+	"logo_med",
+	"thumb",
+	"micro",
+	"720p",
+	"1080p",
+	"original",
+}
+
+// indexFileName is the name of the file within the image directory that maps
+// the downloaded image URLs to their local files.
+const indexFileName = "index.json"
+
+// igdbSizePattern matches the size preset segment of an IGDB image URL path.
+var igdbSizePattern = regexp.MustCompile(`/t_[a-z0-9_]+/`)
+
+// DownloadError describes an image that could not be downloaded.
+//
+// Fields:
+//   - URL: The URL of the image.
+//   - StatusCode: The HTTP status code of the response, zero if no response was received.
+//   - Err: The underlying error, if any.
+type DownloadError struct {
+	URL        string
+	StatusCode int
+	Err        error
+}
+
+func (e *DownloadError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("downloading image %s failed: %v", e.URL, e.Err)
+	}
+
+	return fmt.Sprintf("downloading image %s failed with status %d", e.URL, e.StatusCode)
+}
+
+func (e *DownloadError) Unwrap() error {
+	return e.Err
+}
+
+// ValidSize reports whether the size is one of the IGDB image size presets.
+func ValidSize(size string) bool {
+	return slices.Contains(Sizes, size)
+}
+
+// SizedURL returns the absolute URL of an IGDB image in the size preset. IGDB
+// returns protocol-relative URLs, which are completed with https. URLs without
+// a size preset are returned unchanged.
+//
+// Parameters:
+//   - imageURL: The image URL as returned by IGDB.
+//   - size: One of the Sizes presets.
+//
+// Returns:
+//   - The URL of the image in the size preset.
+func SizedURL(imageURL string, size string) string {
+	if strings.HasPrefix(imageURL, "//") {
+		imageURL = "https:" + imageURL
+	}
+
+	if loc := igdbSizePattern.FindStringIndex(imageURL); loc != nil {
+		imageURL = imageURL[:loc[0]] + "/t_" + size + "/" + imageURL[loc[1]:]
+	}
+
+	return imageURL
+}
+
+// Options selects which images of a game are downloaded and in which size.
+//
+// Fields:
+//   - Size: The IGDB image size preset, DefaultSize when empty.
+//   - Artworks: Whether to download the artworks as well as the cover.
+//   - Screenshots: Whether to download the screenshots as well as the cover.
+type Options struct {
+	Size        string
+	Artworks    bool
+	Screenshots bool
+}
+
+// Store is a content-addressed directory of downloaded images. Images are named
+// after the SHA-256 of their content, so an image shared by several games is
+// stored once, and an index of downloaded URLs lets later runs skip images
+// that are already stored.
+type Store struct {
+	dir        string
+	httpClient *http.Client
+	index      map[string]string
+}
+
+// NewStore opens the image directory, creating it if needed, and loads the
+// index of previously downloaded images.
+//
+// Parameters:
+//   - dir: The directory images are stored in.
+//   - httpClient: The HTTP client to download images with, a new client when nil.
+//
+// Returns:
+//   - A pointer to the Store.
+//   - error: An error if the directory cannot be created or the index cannot be read.
+func NewStore(dir string, httpClient *http.Client) (*Store, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	if err := os.MkdirAll(dir, fs.FileMode(0755)); err != nil {
+		return nil, err
+	}
+
+	store := &Store{dir: dir, httpClient: httpClient, index: map[string]string{}}
+
+	data, err := os.ReadFile(filepath.Join(dir, indexFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &store.index); err != nil {
+		return nil, fmt.Errorf("invalid image index %s: %w", filepath.Join(dir, indexFileName), err)
+	}
+
+	return store, nil
+}
+
+// Fetch downloads the image unless it is already stored and returns the path
+// of its local file.
+//
+// Parameters:
+//   - imageURL: The absolute URL of the image.
+//
+// Returns:
+//   - The path of the local image file.
+//   - error: A *DownloadError if the image could not be downloaded, otherwise nil.
+func (s *Store) Fetch(imageURL string) (string, error) {
+	if name, ok := s.index[imageURL]; ok {
+		localPath := filepath.Join(s.dir, name)
+		if _, err := os.Stat(localPath); err == nil {
+			return localPath, nil
+		}
+	}
+
+	response, err := s.httpClient.Get(imageURL)
+	if err != nil {
+		return "", &DownloadError{URL: imageURL, Err: err}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", &DownloadError{URL: imageURL, StatusCode: response.StatusCode}
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", &DownloadError{URL: imageURL, StatusCode: response.StatusCode, Err: err}
+	}
+
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:]) + imageExtension(imageURL)
+	localPath := filepath.Join(s.dir, name)
+
+	if _, err := os.Stat(localPath); err != nil {
+		if err := write.WriteFile(data, localPath); err != nil {
+			return "", &DownloadError{URL: imageURL, StatusCode: response.StatusCode, Err: err}
+		}
+	}
+
+	s.index[imageURL] = name
+	return localPath, nil
+}
+
+// LocalizeGame downloads the selected images of the game and records the paths
+// of their local files on the game.
+//
+// Parameters:
+//   - game: The game whose images to download.
+//   - options: The images to download and their size.
+//
+// Returns:
+//   - error: The joined *DownloadError of every image that could not be downloaded, otherwise nil.
+func (s *Store) LocalizeGame(game *domain.Game, options Options) error {
+	size := options.Size
+	if size == "" {
+		size = DefaultSize
+	}
+
+	var errs []error
+	localize := func(imageURL string, localPath *string) {
+		if imageURL == "" {
+			return
+		}
+
+		fetched, err := s.Fetch(SizedURL(imageURL, size))
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		*localPath = fetched
+	}
+
+	localize(game.Cover.URL, &game.Cover.LocalPath)
+
+	if options.Artworks {
+		for i := range game.Artworks {
+			localize(game.Artworks[i].URL, &game.Artworks[i].LocalPath)
+		}
+	}
+
+	if options.Screenshots {
+		for i := range game.Screenshots {
+			localize(game.Screenshots[i].URL, &game.Screenshots[i].LocalPath)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SaveIndex writes the index of downloaded images to the image directory.
+func (s *Store) SaveIndex() error {
+	data, err := json.MarshalIndent(s.index, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return write.WriteFile(data, filepath.Join(s.dir, indexFileName))
+}
+
+// imageExtension returns the normalized file extension of the image URL.
+func imageExtension(imageURL string) string {
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return normalizeExtension("")
+	}
+
+	return normalizeExtension(path.Ext(parsed.Path))
+}
+
+// normalizeExtension lowercases an image file extension and spells JPEG
+// images as .jpg, which is also assumed for images without an extension.
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(ext)
+	if ext == "" || ext == ".jpeg" {
+		return ".jpg"
+	}
+
+	return ext
+}
@@ -0,0 +1,166 @@
+package images
+
+import (
+	"errors"
+	"main/src/domain"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newTestImageServer serves an image for every path below /igdb/image/upload/,
+// the same content for every size, and 404 for anything else.
+func newTestImageServer() (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	requested := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		if !strings.HasPrefix(r.URL.Path, "/igdb/image/upload/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("image data for " + filepath.Base(r.URL.Path)))
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, requested...)
+	}
+}
+
+func TestSizedURL(t *testing.T) {
+	tests := []struct {
+		imageURL string
+		size     string
+		expected string
+	}{
+		{"//images.igdb.com/igdb/image/upload/t_thumb/co1j8f.jpg", "cover_big", "https://images.igdb.com/igdb/image/upload/t_cover_big/co1j8f.jpg"},
+		{"https://images.igdb.com/igdb/image/upload/t_cover_big/co1j8f.jpg", "1080p", "https://images.igdb.com/igdb/image/upload/t_1080p/co1j8f.jpg"},
+		{"http://localhost/cover.png", "cover_big", "http://localhost/cover.png"},
+	}
+
+	for _, tt := range tests {
+		if actual := SizedURL(tt.imageURL, tt.size); actual != tt.expected {
+			t.Errorf("Expected SizedURL(%q, %q) to be %q, but got %q", tt.imageURL, tt.size, tt.expected, actual)
+		}
+	}
+
+	if !ValidSize("screenshot_huge") || ValidSize("huge") {
+		t.Errorf("Expected only IGDB size presets to be valid")
+	}
+}
+
+func TestLocalizeGame(t *testing.T) {
+	server, requested := newTestImageServer()
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "images")
+	store, err := NewStore(dir, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	game := domain.Game{
+		Cover: domain.Cover{URL: server.URL + "/igdb/image/upload/t_thumb/co1j8f.jpg"},
+		Artworks: []domain.Artwork{
+			{URL: server.URL + "/igdb/image/upload/t_thumb/ar7nlx.jpg"},
+			// the same image as the cover, stored once
+			{URL: server.URL + "/igdb/image/upload/t_thumb/co1j8f.jpg"},
+		},
+		Screenshots: []domain.Artwork{{URL: server.URL + "/igdb/image/upload/t_thumb/sc7hl.jpg"}},
+	}
+
+	// Execution
+	err = store.LocalizeGame(&game, Options{Size: "cover_big", Artworks: true})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	// Assertion
+	data, err := os.ReadFile(game.Cover.LocalPath)
+	if err != nil || string(data) != "image data for co1j8f.jpg" {
+		t.Errorf("Expected the cover to be stored locally, but got %q, %v", data, err)
+	}
+
+	if game.Artworks[0].LocalPath == "" || game.Artworks[0].LocalPath == game.Cover.LocalPath {
+		t.Errorf("Expected the artwork to be stored in its own file, but got %q", game.Artworks[0].LocalPath)
+	}
+
+	if game.Artworks[1].LocalPath != game.Cover.LocalPath {
+		t.Errorf("Expected identical images to share a file, but got %q and %q", game.Artworks[1].LocalPath, game.Cover.LocalPath)
+	}
+
+	if game.Screenshots[0].LocalPath != "" {
+		t.Errorf("Expected screenshots not to be downloaded, but got %q", game.Screenshots[0].LocalPath)
+	}
+
+	for _, path := range requested() {
+		if !strings.Contains(path, "/t_cover_big/") {
+			t.Errorf("Expected images to be requested in the cover_big size, but got %s", path)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 content-addressed images, but got %d files", len(entries))
+	}
+
+	// a later run should reuse the downloaded images
+	if err := store.SaveIndex(); err != nil {
+		t.Fatalf("Expected no error saving the index, but got %v", err)
+	}
+
+	requestCount := len(requested())
+	reopened, err := NewStore(dir, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	rerun := domain.Game{Cover: domain.Cover{URL: game.Cover.URL}}
+	if err := reopened.LocalizeGame(&rerun, Options{Size: "cover_big"}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if len(requested()) != requestCount {
+		t.Errorf("Expected the stored cover not to be downloaded again, but got requests %v", requested()[requestCount:])
+	}
+
+	if rerun.Cover.LocalPath != game.Cover.LocalPath {
+		t.Errorf("Expected the stored cover path %q, but got %q", game.Cover.LocalPath, rerun.Cover.LocalPath)
+	}
+}
+
+func TestLocalizeGameDownloadError(t *testing.T) {
+	server, _ := newTestImageServer()
+	defer server.Close()
+
+	store, err := NewStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	game := domain.Game{Cover: domain.Cover{URL: server.URL + "/missing/cover.jpg"}}
+
+	// Execution
+	err = store.LocalizeGame(&game, Options{})
+
+	// Assertion
+	var downloadErr *DownloadError
+	if !errors.As(err, &downloadErr) || downloadErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected a DownloadError with status 404, but got %v", err)
+	}
+
+	if game.Cover.LocalPath != "" {
+		t.Errorf("Expected no local path for a failed download, but got %q", game.Cover.LocalPath)
+	}
+}
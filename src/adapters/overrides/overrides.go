@@ -0,0 +1,68 @@
+package overrides
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"main/src/adapters/write"
+	"main/src/domain"
+	"os"
+)
+
+type overridesFile struct {
+	Overrides domain.MatchOverrides `json:"overrides"`
+}
+
+// LoadFile reads manual IGDB match overrides from a JSON file of the form
+//
+//	{"overrides": [{"clz_id": 812, "igdb_id": 8008}, {"title": "Tokobot", "platform": "PSP", "skip": true}]}
+//
+// A missing file is treated as an empty set of overrides.
+//
+// Parameters:
+//   - filename: The path of the overrides file.
+//
+// Returns:
+//   - domain.MatchOverrides: The overrides read from the file.
+//   - error: An error if the file exists but cannot be read or parsed, otherwise nil.
+func LoadFile(filename string) (domain.MatchOverrides, error) {
+	if filename == "" {
+		return domain.MatchOverrides{}, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.MatchOverrides{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file overridesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	return file.Overrides, nil
+}
+
+// SaveFile writes manual IGDB match overrides to a JSON file readable by LoadFile.
+//
+// Parameters:
+//   - filename: The path of the overrides file.
+//   - overrides: The overrides to write.
+//
+// Returns:
+//   - error: An error if the overrides cannot be marshalled or written, otherwise nil.
+func SaveFile(filename string, overrides domain.MatchOverrides) error {
+	if overrides == nil {
+		overrides = domain.MatchOverrides{}
+	}
+
+	data, err := json.MarshalIndent(overridesFile{Overrides: overrides}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return write.WriteFile(append(data, '\n'), filename)
+}
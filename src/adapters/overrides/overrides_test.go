@@ -0,0 +1,35 @@
+package overrides
+
+import (
+	"main/src/domain"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveAndLoadFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "overrides.json")
+	expected := domain.MatchOverrides{
+		{CLZ_ID: 812, IGDB_ID: 8008},
+		{Title: "Tokobot", Platform: "PSP", Skip: true},
+	}
+
+	err := SaveFile(filename, expected)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	loaded, err := LoadFile(filename)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("expected %+v, got %+v", expected, loaded)
+	}
+
+	missing, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(missing) != 0 {
+		t.Errorf("expected no overrides and no error for a missing file, got %+v, %v", missing, err)
+	}
+}
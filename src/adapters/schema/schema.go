@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"main/src/domain"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// draft is the JSON Schema dialect of the generated schema.
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// knownTypes are the schemas of the types with a custom JSON encoding, which
+// cannot be derived from their Go definition.
+var knownTypes = map[reflect.Type]map[string]interface{}{
+	reflect.TypeFor[time.Time](): {
+		"type":   "string",
+		"format": "date-time",
+	},
+	reflect.TypeFor[domain.Date](): {
+		"type":        []string{"string", "null"},
+		"pattern":     `^\d{4}(-\d{2}(-\d{2})?)?$`,
+		"description": "A date known to the year, month or day, null when unknown.",
+	},
+}
+
+// Generate returns the JSON Schema of the translated collection JSON output,
+// generated from the domain.GameCollection type and its json tags. Every
+// struct type is defined once under $defs and referenced by name.
+//
+// Returns:
+//   - The JSON Schema, indented.
+//   - error: An error if the schema cannot be generated or marshalled, otherwise nil.
+func Generate() ([]byte, error) {
+	g := &generator{defs: map[string]interface{}{}}
+
+	root, err := g.structSchema(reflect.TypeFor[domain.GameCollection]())
+	if err != nil {
+		return nil, err
+	}
+
+	root["$schema"] = draft
+	root["title"] = "CLZ game collection"
+	root["properties"].(map[string]interface{})["schema_version"] = map[string]interface{}{
+		"type":  "integer",
+		"const": domain.SchemaVersion,
+	}
+	root["$defs"] = g.defs
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+type generator struct {
+	defs map[string]interface{}
+}
+
+// schemaFor returns the schema of a value of the type, a reference to its
+// definition for structs.
+func (g *generator) schemaFor(t reflect.Type) (map[string]interface{}, error) {
+	if known, ok := knownTypes[t]; ok {
+		return known, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Pointer:
+		return g.schemaFor(t.Elem())
+	case reflect.Slice, reflect.Array:
+		items, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			// reserved first, so recursive types terminate
+			g.defs[t.Name()] = nil
+			definition, err := g.structSchema(t)
+			if err != nil {
+				return nil, err
+			}
+			g.defs[t.Name()] = definition
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// structSchema returns the object schema of the struct type. Fields without
+// omitempty are required, and lists and maps without it may also be null, as
+// Go encodes nil lists and maps.
+func (g *generator) structSchema(t reflect.Type) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		property, err := g.schemaFor(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		if !omitEmpty {
+			required = append(required, name)
+
+			if kind := field.Type.Kind(); kind == reflect.Slice || kind == reflect.Map {
+				property = map[string]interface{}{"anyOf": []interface{}{property, map[string]interface{}{"type": "null"}}}
+			}
+		}
+
+		properties[name] = property
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}, nil
+}
+
+// jsonName returns the JSON key of the struct field and whether it is omitted when empty.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, strings.Contains(","+options+",", ",omitempty,")
+}
@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"main/src/domain"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestGenerateMatchesSchemaVersion guards the output schema: the schema of
+// every released domain.SchemaVersion is recorded in the test data, and must
+// never be edited. Changing the output schema therefore fails this test until
+// domain.SchemaVersion is bumped and the new schema is recorded next to the old ones.
+func TestGenerateMatchesSchemaVersion(t *testing.T) {
+	actual, err := Generate()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	filename := fmt.Sprintf("../../_test/data/schema/game-collection.v%d.schema.json", domain.SchemaVersion)
+	expected, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Expected the schema of version %d to be recorded in %s, but got %v", domain.SchemaVersion, filename, err)
+	}
+
+	if string(actual)+"\n" != string(expected) {
+		t.Errorf("Expected the schema to match %s; the JSON output changed, bump domain.SchemaVersion and record the new schema instead of editing an existing one", filename)
+	}
+
+	for version := 1; version < domain.SchemaVersion; version++ {
+		previous := fmt.Sprintf("../../_test/data/schema/game-collection.v%d.schema.json", version)
+		if _, err := os.Stat(previous); err != nil {
+			t.Errorf("Expected the schema of released version %d to be kept in %s, but got %v", version, previous, err)
+		}
+	}
+}
+
+func TestGenerateCoversOutput(t *testing.T) {
+	data, err := Generate()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	var generated struct {
+		Defs map[string]struct {
+			Properties map[string]interface{} `json:"properties"`
+			Required   []string               `json:"required"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &generated); err != nil {
+		t.Fatalf("Expected the schema to be valid JSON, but got %v", err)
+	}
+	gameSchema := generated.Defs["Game"]
+
+	full := domain.Game{
+		Artworks:         []domain.Artwork{{ID: 1, URL: "//images.igdb.com/a.jpg"}},
+		AudienceRating:   "E",
+		BPGameID:         28697,
+		CLZ_ID:           1,
+		Developers:       []string{"Nintendo"},
+		FirstReleaseDate: time.Date(1990, time.February, 12, 0, 0, 0, 0, time.UTC),
+		IGDB_ID:          1068,
+		Loans:            []domain.Loan{{Loaner: "Sam"}},
+		Platform:         domain.NES,
+		Provenance:       domain.Provenance{Genres: map[string][]domain.Source{"Platform": {domain.SourceIGDB}}},
+		ReleaseDate:      domain.NewDate(1990, 2, 0),
+		Title:            "Super Mario Bros. 3",
+		Videos:           []domain.Video{{Name: "Trailer", VideoID: "mR6ZfUwPgqk"}},
+	}
+
+	var fullKeys map[string]interface{}
+	encoded, _ := json.Marshal(full)
+	json.Unmarshal(encoded, &fullKeys)
+	for key := range fullKeys {
+		if _, ok := gameSchema.Properties[key]; !ok {
+			t.Errorf("Expected output key %q to be described by the schema", key)
+		}
+	}
+
+	var emptyKeys map[string]interface{}
+	encoded, _ = json.Marshal(domain.Game{})
+	json.Unmarshal(encoded, &emptyKeys)
+	for _, key := range gameSchema.Required {
+		if _, ok := emptyKeys[key]; !ok {
+			t.Errorf("Expected required key %q to be present in the output of an empty game", key)
+		}
+	}
+	if len(emptyKeys) != len(gameSchema.Required) {
+		t.Errorf("Expected an empty game to only output the %d required keys, but got %d", len(gameSchema.Required), len(emptyKeys))
+	}
+}
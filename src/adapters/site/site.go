@@ -0,0 +1,387 @@
+package site
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"main/src/adapters/images"
+	"main/src/adapters/write"
+	"main/src/domain"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultTitle is the title of the site when none is configured.
+const DefaultTitle = "Game Collection"
+
+// coverSize is the IGDB image size preset of covers not downloaded locally.
+const coverSize = "cover_big"
+
+// templates holds the default page templates and static assets. The page
+// templates define "index.html", "group.html" and "game.html", sharing the
+// "header" and "footer" templates of layout.html.
+//
+//go:embed templates
+var templates embed.FS
+
+// staticAssets are the files copied to the site as they are.
+var staticAssets = []string{"style.css", "search.js"}
+
+var nonSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Options configures the generated site.
+//
+// Fields:
+//   - Title: The title of the site, DefaultTitle when empty.
+//   - TemplatesDir: A directory of templates and assets overriding the defaults by file name, if any.
+type Options struct {
+	Title        string
+	TemplatesDir string
+}
+
+// gamePage is a game with the paths of its detail page and the pages it links to.
+type gamePage struct {
+	Game         domain.Game
+	Path         string
+	CoverURL     string
+	PlatformPath string
+	Genres       []*group
+	Series       *group
+}
+
+// group is an index page listing the games sharing a platform, genre or series.
+type group struct {
+	Kind  string
+	Name  string
+	Path  string
+	Games []*gamePage
+}
+
+// section lists the groups of one kind on the index page.
+type section struct {
+	Title  string
+	Groups []*group
+}
+
+// page is the data every template is executed with. Root is the relative path
+// from the page to the root of the site, so the site works from file://.
+type page struct {
+	SiteTitle string
+	Title     string
+	Root      string
+	GameCount int
+	Sections  []section
+	Group     *group
+	Game      *gamePage
+}
+
+// searchEntry is a game in the client-side search index.
+type searchEntry struct {
+	Title    string `json:"title"`
+	Platform string `json:"platform"`
+	Path     string `json:"path"`
+	Text     string `json:"text"`
+}
+
+// Generate writes a static site browsing the collection to the output
+// directory: an index page, index pages per platform, genre and series, a
+// detail page per game and a search index. All links are relative, so the
+// site can be opened from the file system without a server.
+//
+// Parameters:
+//   - collection: The translated collection.
+//   - outputDir: The directory to write the site to, created if needed.
+//   - options: The title and template overrides of the site.
+//
+// Returns:
+//   - error: An error if the templates are invalid or the site cannot be written, otherwise nil.
+func Generate(collection domain.GameCollection, outputDir string, options Options) error {
+	siteTitle := options.Title
+	if siteTitle == "" {
+		siteTitle = DefaultTitle
+	}
+
+	pageTemplates, err := loadTemplates(options.TemplatesDir)
+	if err != nil {
+		return err
+	}
+
+	games, sections, err := buildPages(collection.Games, outputDir)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range []string{"", "games", "platforms", "genres", "series"} {
+		if err := os.MkdirAll(filepath.Join(outputDir, dir), fs.FileMode(0755)); err != nil {
+			return err
+		}
+	}
+
+	render := func(name string, pagePath string, data page) error {
+		data.SiteTitle = siteTitle
+		data.Root = strings.Repeat("../", strings.Count(pagePath, "/"))
+
+		var buffer bytes.Buffer
+		if err := pageTemplates.ExecuteTemplate(&buffer, name, data); err != nil {
+			return fmt.Errorf("error rendering %s: %w", pagePath, err)
+		}
+
+		return write.WriteFile(buffer.Bytes(), filepath.Join(outputDir, filepath.FromSlash(pagePath)))
+	}
+
+	if err := render("index.html", "index.html", page{GameCount: len(games), Sections: sections}); err != nil {
+		return err
+	}
+
+	for _, section := range sections {
+		for _, g := range section.Groups {
+			if err := render("group.html", g.Path, page{Title: g.Name, Group: g}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, game := range games {
+		if err := render("game.html", game.Path, page{Title: game.Game.Title, Game: game}); err != nil {
+			return err
+		}
+	}
+
+	if err := writeSearchIndex(games, outputDir); err != nil {
+		return err
+	}
+
+	return copyStaticAssets(options.TemplatesDir, outputDir)
+}
+
+// loadTemplates parses the default templates, then the .html files of the
+// templates directory, whose definitions replace the defaults of the same name.
+func loadTemplates(templatesDir string) (*template.Template, error) {
+	pageTemplates, err := template.New("site").Funcs(template.FuncMap{"join": strings.Join}).ParseFS(templates, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	if templatesDir == "" {
+		return pageTemplates, nil
+	}
+
+	overrides, err := filepath.Glob(filepath.Join(templatesDir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	if len(overrides) == 0 {
+		return pageTemplates, nil
+	}
+
+	pageTemplates, err = pageTemplates.ParseFiles(overrides...)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing templates in %s: %w", templatesDir, err)
+	}
+
+	return pageTemplates, nil
+}
+
+// copyStaticAssets writes the static assets to the site, preferring the
+// templates directory's copy of an asset over the default.
+func copyStaticAssets(templatesDir string, outputDir string) error {
+	for _, name := range staticAssets {
+		data, err := fs.ReadFile(templates, "templates/"+name)
+		if err != nil {
+			return err
+		}
+
+		if templatesDir != "" {
+			override, err := os.ReadFile(filepath.Join(templatesDir, name))
+			if err == nil {
+				data = override
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+
+		if err := write.WriteFile(data, filepath.Join(outputDir, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildPages returns the detail pages of the games, sorted by title, and the
+// platform, genre and series sections of the index page.
+func buildPages(games []domain.Game, outputDir string) ([]*gamePage, []section, error) {
+	pages := make([]*gamePage, 0, len(games))
+	for _, game := range games {
+		coverURL, err := coverURL(game.Cover, outputDir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pages = append(pages, &gamePage{Game: game, CoverURL: coverURL})
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		return strings.ToLower(pages[i].Game.Title) < strings.ToLower(pages[j].Game.Title)
+	})
+
+	gameSlugs := newSlugger()
+	for _, p := range pages {
+		name := p.Game.Title
+		if p.Game.CLZ_ID != 0 {
+			name = strconv.Itoa(p.Game.CLZ_ID) + " " + name
+		}
+		p.Path = "games/" + gameSlugs.slug(name) + ".html"
+	}
+
+	platforms := newGrouping("Platform", "platforms")
+	genres := newGrouping("Genre", "genres")
+	series := newGrouping("Series", "series")
+
+	for _, p := range pages {
+		if platform := platforms.add(string(p.Game.Platform), p); platform != nil {
+			p.PlatformPath = platform.Path
+		}
+		for _, genre := range p.Game.Genres {
+			if g := genres.add(genre, p); g != nil {
+				p.Genres = append(p.Genres, g)
+			}
+		}
+		p.Series = series.add(p.Game.Series, p)
+	}
+
+	sections := []section{
+		{Title: "Platforms", Groups: platforms.sorted()},
+		{Title: "Genres", Groups: genres.sorted()},
+		{Title: "Series", Groups: series.sorted()},
+	}
+
+	return pages, sections, nil
+}
+
+// coverURL returns the cover image of a page in the games directory: the
+// downloaded or imported local file, relative to the page, otherwise the IGDB URL.
+func coverURL(cover domain.Cover, outputDir string) (string, error) {
+	if cover.LocalPath != "" {
+		localPath, err := filepath.Abs(cover.LocalPath)
+		if err != nil {
+			return "", err
+		}
+		gamesDir, err := filepath.Abs(filepath.Join(outputDir, "games"))
+		if err != nil {
+			return "", err
+		}
+		relative, err := filepath.Rel(gamesDir, localPath)
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.ToSlash(relative), nil
+	}
+
+	if cover.URL != "" {
+		return images.SizedURL(cover.URL, coverSize), nil
+	}
+
+	return "", nil
+}
+
+func writeSearchIndex(pages []*gamePage, outputDir string) error {
+	entries := make([]searchEntry, 0, len(pages))
+	for _, p := range pages {
+		text := append([]string{p.Game.Title, string(p.Game.Platform), p.Game.Series}, p.Game.Genres...)
+		entries = append(entries, searchEntry{
+			Title:    p.Game.Title,
+			Platform: string(p.Game.Platform),
+			Path:     p.Path,
+			Text:     strings.Join(text, " "),
+		})
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	// a script rather than JSON, as browsers do not fetch files from file://
+	script := append([]byte("window.CLZ_SEARCH_INDEX = "), data...)
+	script = append(script, ";\n"...)
+
+	return write.WriteFile(script, filepath.Join(outputDir, "search-index.js"))
+}
+
+// grouping collects the groups of one kind, keyed by name.
+type grouping struct {
+	kind   string
+	dir    string
+	slugs  *slugger
+	groups map[string]*group
+}
+
+func newGrouping(kind string, dir string) *grouping {
+	return &grouping{kind: kind, dir: dir, slugs: newSlugger(), groups: map[string]*group{}}
+}
+
+// add adds the game to the group of the name, returning the group, or nil for an empty name.
+func (g *grouping) add(name string, p *gamePage) *group {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	existing, ok := g.groups[name]
+	if !ok {
+		existing = &group{Kind: g.kind, Name: name, Path: path.Join(g.dir, g.slugs.slug(name)+".html")}
+		g.groups[name] = existing
+	}
+	existing.Games = append(existing.Games, p)
+
+	return existing
+}
+
+func (g *grouping) sorted() []*group {
+	groups := make([]*group, 0, len(g.groups))
+	for _, existing := range g.groups {
+		groups = append(groups, existing)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+	})
+
+	return groups
+}
+
+// slugger turns names into unique file name slugs.
+type slugger struct {
+	used map[string]bool
+}
+
+func newSlugger() *slugger {
+	return &slugger{used: map[string]bool{}}
+}
+
+// slug returns the slug of the name, numbered when an earlier name had the same slug.
+func (s *slugger) slug(name string) string {
+	base := strings.Trim(nonSlugPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if base == "" {
+		base = "untitled"
+	}
+
+	slug := base
+	for n := 2; s.used[slug]; n++ {
+		slug = base + "-" + strconv.Itoa(n)
+	}
+	s.used[slug] = true
+
+	return slug
+}
@@ -0,0 +1,159 @@
+package site
+
+import (
+	"main/src/domain"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var testCollection = domain.GameCollection{
+	SchemaVersion: domain.SchemaVersion,
+	Games: []domain.Game{
+		{
+			CLZ_ID:       2474,
+			Title:        "8 Eyes",
+			Platform:     domain.NES,
+			Genres:       []string{"Action"},
+			Completeness: domain.Completeness{HasGame: true},
+		},
+		{
+			CLZ_ID:       1,
+			Title:        "Super Mario Bros. 3",
+			Platform:     domain.NES,
+			Genres:       []string{"Platform", "Action"},
+			Series:       "Super Mario",
+			Summary:      "Mario <3 the Mushroom Kingdom.",
+			Cover:        domain.Cover{URL: "//images.igdb.com/igdb/image/upload/t_thumb/co1j8f.jpg"},
+			Completeness: domain.Completeness{HasBox: true, HasGame: true},
+			Links:        []domain.Link{{Description: "Manual", URL: "https://example.com/manual"}},
+		},
+		{
+			CLZ_ID:   812,
+			Title:    "1Xtreme (Greatest Hits)",
+			Platform: domain.PlayStation,
+		},
+	},
+}
+
+func readSiteFile(t *testing.T, dir string, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("Expected %s to be written, but got %v", name, err)
+	}
+
+	return string(data)
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Generate(testCollection, dir, Options{}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	index := readSiteFile(t, dir, "index.html")
+	for _, expected := range []string{
+		"<title>Game Collection</title>",
+		`href="platforms/nes.html"`,
+		`href="platforms/playstation.html"`,
+		`href="genres/action.html"`,
+		`href="series/super-mario.html"`,
+		`<script src="search-index.js"></script>`,
+	} {
+		if !strings.Contains(index, expected) {
+			t.Errorf("Expected the index page to contain %s", expected)
+		}
+	}
+
+	platform := readSiteFile(t, dir, "platforms/nes.html")
+	if !strings.Contains(platform, `href="../games/1-super-mario-bros-3.html"`) || !strings.Contains(platform, `href="../games/2474-8-eyes.html"`) {
+		t.Errorf("Expected the NES page to link both NES games, but got\n%s", platform)
+	}
+	if strings.Contains(platform, "1Xtreme") {
+		t.Errorf("Expected the NES page not to list PlayStation games")
+	}
+
+	game := readSiteFile(t, dir, "games/1-super-mario-bros-3.html")
+	for _, expected := range []string{
+		`src="https://images.igdb.com/igdb/image/upload/t_cover_big/co1j8f.jpg"`,
+		"Mario &lt;3 the Mushroom Kingdom.",
+		`<span class="badge owned">Box</span>`,
+		`<span class="badge">Manual</span>`,
+		`<a href="../genres/platform.html">Platform</a>`,
+		`<a href="https://example.com/manual">Manual</a>`,
+		`<link rel="stylesheet" href="../style.css">`,
+	} {
+		if !strings.Contains(game, expected) {
+			t.Errorf("Expected the game page to contain %s", expected)
+		}
+	}
+
+	// every page must work from file://, so no link may be absolute to the site root
+	rootLink := regexp.MustCompile(`(href|src)="/[^/]`)
+	filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err == nil && strings.HasSuffix(path, ".html") {
+			data, _ := os.ReadFile(path)
+			if rootLink.Match(data) {
+				t.Errorf("Expected only relative links in %s", path)
+			}
+		}
+		return nil
+	})
+
+	searchIndex := readSiteFile(t, dir, "search-index.js")
+	if !strings.HasPrefix(searchIndex, "window.CLZ_SEARCH_INDEX = [") || !strings.Contains(searchIndex, `"path":"games/812-1xtreme-greatest-hits.html"`) {
+		t.Errorf("Expected the search index to list every game, but got %s", searchIndex)
+	}
+
+	for _, asset := range staticAssets {
+		readSiteFile(t, dir, asset)
+	}
+}
+
+func TestGenerateTemplateOverrides(t *testing.T) {
+	templatesDir := t.TempDir()
+	os.WriteFile(filepath.Join(templatesDir, "footer.html"), []byte(`{{define "footer"}}<footer>Branded {{.SiteTitle}}</footer></body></html>{{end}}`), 0644)
+	os.WriteFile(filepath.Join(templatesDir, "style.css"), []byte("body { color: hotpink; }"), 0644)
+
+	dir := t.TempDir()
+	if err := Generate(testCollection, dir, Options{Title: "Joe's Games", TemplatesDir: templatesDir}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	index := readSiteFile(t, dir, "index.html")
+	if !strings.Contains(index, "<footer>Branded Joe&#39;s Games</footer>") {
+		t.Errorf("Expected the overridden footer, but got\n%s", index)
+	}
+	if !strings.Contains(index, `href="platforms/nes.html"`) {
+		t.Errorf("Expected templates that are not overridden to be kept")
+	}
+	if style := readSiteFile(t, dir, "style.css"); style != "body { color: hotpink; }" {
+		t.Errorf("Expected the overridden stylesheet, but got %s", style)
+	}
+	if search := readSiteFile(t, dir, "search.js"); !strings.Contains(search, "CLZ_SEARCH_INDEX") {
+		t.Errorf("Expected the default search script")
+	}
+
+	os.WriteFile(filepath.Join(templatesDir, "broken.html"), []byte(`{{define "index.html"}}{{.Missing`), 0644)
+	if err := Generate(testCollection, t.TempDir(), Options{TemplatesDir: templatesDir}); err == nil {
+		t.Errorf("Expected an error for a broken template")
+	}
+}
+
+func TestCoverURL(t *testing.T) {
+	dir := t.TempDir()
+	cover := domain.Cover{URL: "//images.igdb.com/igdb/image/upload/t_thumb/co1j8f.jpg", LocalPath: filepath.Join(dir, "images", "abc.jpg")}
+
+	actual, err := coverURL(cover, filepath.Join(dir, "site"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if actual != "../../images/abc.jpg" {
+		t.Errorf("Expected the local cover relative to the game pages, but got %s", actual)
+	}
+}
@@ -0,0 +1,28 @@
+// Filters the search index written to search-index.js as the search box is
+// typed in. The index is loaded as a script, so it also works from file://.
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("search-results");
+  var root = document.body.dataset.root || "";
+  var index = window.CLZ_SEARCH_INDEX || [];
+
+  input.addEventListener("input", function () {
+    var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+    results.innerHTML = "";
+    if (terms.length === 0) {
+      return;
+    }
+
+    index.filter(function (entry) {
+      var text = entry.text.toLowerCase();
+      return terms.every(function (term) { return text.indexOf(term) !== -1; });
+    }).slice(0, 50).forEach(function (entry) {
+      var link = document.createElement("a");
+      link.href = root + entry.path;
+      link.textContent = entry.title + " (" + entry.platform + ")";
+      var item = document.createElement("li");
+      item.appendChild(link);
+      results.appendChild(item);
+    });
+  });
+})();
@@ -0,0 +1,78 @@
+package write
+
+import (
+	"fmt"
+	"io"
+	"main/src/domain"
+	"strings"
+)
+
+// Output formats games can be written in.
+const (
+	FormatJSON     = "json"
+	FormatNDJSON   = "ndjson"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatSQLite   = "sqlite"
+	FormatMarkdown = "markdown"
+)
+
+// Formats are the supported output formats.
+var Formats = []string{FormatJSON, FormatNDJSON, FormatCSV, FormatTSV, FormatSQLite, FormatMarkdown}
+
+// FormatOptions configures the output formats that can be customized.
+//
+// Fields:
+//   - Table: The columns and list delimiter of the CSV and TSV formats.
+//   - MarkdownTemplate: The text/template source of the Markdown report, DefaultMarkdownTemplate when empty.
+type FormatOptions struct {
+	Table            TableOptions
+	MarkdownTemplate string
+}
+
+// Extension returns the file extension of the output format, without the dot.
+func Extension(format string) string {
+	if format == FormatMarkdown {
+		return "md"
+	}
+
+	return format
+}
+
+// GameWriter writes translated games one at a time.
+type GameWriter interface {
+	// WriteGame writes a single game.
+	WriteGame(game domain.Game) error
+	// Close terminates the output without closing the underlying writer.
+	Close() error
+}
+
+// NewGameWriter creates the GameWriter for an output format written as a
+// stream. The FormatSQLite database is written with NewSQLiteWriter instead.
+//
+// Parameters:
+//   - format: One of the Formats.
+//   - writer: The io.Writer the games will be written to.
+//   - options: The options of the format, ignored by formats without options.
+//
+// Returns:
+//   - The GameWriter for the format.
+//   - error: An error if the format is not supported or its options are invalid, otherwise nil.
+func NewGameWriter(format string, writer io.Writer, options FormatOptions) (GameWriter, error) {
+	switch format {
+	case FormatJSON:
+		return NewJSONCollectionWriter(writer), nil
+	case FormatNDJSON:
+		return NewNDJSONWriter(writer), nil
+	case FormatCSV:
+		return NewTableWriter(writer, ',', options.Table)
+	case FormatTSV:
+		return NewTableWriter(writer, '\t', options.Table)
+	case FormatMarkdown:
+		return NewMarkdownWriter(writer, options.MarkdownTemplate)
+	case FormatSQLite:
+		return nil, fmt.Errorf("the %s format is written to a database file, not a stream", FormatSQLite)
+	}
+
+	return nil, fmt.Errorf("unknown output format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
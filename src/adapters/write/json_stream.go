@@ -0,0 +1,75 @@
+package write
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"main/src/domain"
+)
+
+// JSONCollectionWriter writes a domain.GameCollection as JSON one game at a time,
+// so that a collection can be written out while it is still being translated.
+// The output is identical to marshalling the complete domain.GameCollection.
+type JSONCollectionWriter struct {
+	writer io.Writer
+	count  int
+}
+
+// NewJSONCollectionWriter creates a JSONCollectionWriter writing to the provided writer.
+//
+// Parameters:
+//   - writer: The io.Writer the JSON data will be written to.
+//
+// Returns:
+//   - A pointer to a JSONCollectionWriter instance.
+func NewJSONCollectionWriter(writer io.Writer) *JSONCollectionWriter {
+	return &JSONCollectionWriter{writer: writer}
+}
+
+// WriteGame appends a single game to the collection JSON output.
+//
+// Parameters:
+//   - game: The domain.Game to write.
+//
+// Returns:
+//   - error: An error if marshalling or writing the game fails, otherwise nil.
+func (w *JSONCollectionWriter) WriteGame(game domain.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return err
+	}
+
+	prefix := ","
+	if w.count == 0 {
+		prefix = collectionPrefix()
+	}
+
+	if _, err := io.WriteString(w.writer, prefix); err != nil {
+		return err
+	}
+	if _, err := w.writer.Write(data); err != nil {
+		return err
+	}
+
+	w.count++
+	return nil
+}
+
+// Close terminates the collection JSON output. It does not close the underlying writer.
+//
+// Returns:
+//   - error: An error if writing fails, otherwise nil.
+func (w *JSONCollectionWriter) Close() error {
+	suffix := "]}"
+	if w.count == 0 {
+		suffix = collectionPrefix() + "]}"
+	}
+
+	_, err := io.WriteString(w.writer, suffix)
+	return err
+}
+
+// collectionPrefix returns the start of the collection JSON output up to its first game.
+func collectionPrefix() string {
+	return fmt.Sprintf(`{"schema_version":%d,"games":[`, domain.SchemaVersion)
+}
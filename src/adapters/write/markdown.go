@@ -0,0 +1,188 @@
+package write
+
+import (
+	_ "embed"
+	"io"
+	"main/src/domain"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DefaultMarkdownTemplate is the text/template source of the default Markdown
+// report: a table of contents and a table of games per platform, with totals.
+//
+//go:embed templates/report.md.tmpl
+var DefaultMarkdownTemplate string
+
+var nonAnchorPattern = regexp.MustCompile(`[^a-z0-9 _-]`)
+
+// ReportTotal sums up a set of games in the Markdown report.
+//
+// Fields:
+//   - Games: The number of games.
+//   - Complete: The number of games with their game, box and manual.
+//   - Value: The sum of the PriceCharting values of the games.
+type ReportTotal struct {
+	Games    int
+	Complete int
+	Value    float64
+}
+
+// ReportPlatform is the section of a platform in the Markdown report.
+//
+// Fields:
+//   - Name: The name of the platform.
+//   - Games: The games of the platform, sorted by title.
+//   - Total: The totals of the platform's games.
+type ReportPlatform struct {
+	Name  string
+	Games []domain.Game
+	Total ReportTotal
+}
+
+// Report is the data the Markdown report template is executed with.
+//
+// Fields:
+//   - Platforms: The platforms of the collection, sorted by name.
+//   - Total: The totals of the whole collection.
+type Report struct {
+	Platforms []ReportPlatform
+	Total     ReportTotal
+}
+
+// MarkdownWriter renders games as a Markdown report with a text/template. The
+// report groups the games by platform, so the games are collected as they are
+// written and the report is rendered on Close.
+type MarkdownWriter struct {
+	writer   io.Writer
+	template *template.Template
+	games    []domain.Game
+}
+
+// NewMarkdownWriter creates a MarkdownWriter rendering the template. Besides
+// the text/template builtins, the template can use "anchor" for the link
+// anchor of a heading, "cell" to escape a table cell, "completeness" to list
+// the parts of a game that are present and "money" to format an amount.
+//
+// Parameters:
+//   - writer: The io.Writer the report will be written to.
+//   - source: The text/template source executed with a Report, DefaultMarkdownTemplate when empty.
+//
+// Returns:
+//   - A pointer to a MarkdownWriter instance.
+//   - error: An error if the template cannot be parsed, otherwise nil.
+func NewMarkdownWriter(writer io.Writer, source string) (*MarkdownWriter, error) {
+	if source == "" {
+		source = DefaultMarkdownTemplate
+	}
+
+	reportTemplate, err := template.New("report").Funcs(template.FuncMap{
+		"anchor":       markdownAnchor,
+		"cell":         markdownCell,
+		"completeness": completenessText,
+		"money":        func(amount float64) string { return strconv.FormatFloat(amount, 'f', 2, 64) },
+	}).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarkdownWriter{writer: writer, template: reportTemplate}, nil
+}
+
+// WriteGame adds a single game to the report.
+//
+// Parameters:
+//   - game: The domain.Game to write.
+//
+// Returns:
+//   - error: Always nil, the report is rendered on Close.
+func (w *MarkdownWriter) WriteGame(game domain.Game) error {
+	w.games = append(w.games, game)
+	return nil
+}
+
+// Close renders the report of the written games. It does not close the underlying writer.
+//
+// Returns:
+//   - error: An error if the template fails or writing fails, otherwise nil.
+func (w *MarkdownWriter) Close() error {
+	return w.template.Execute(w.writer, NewReport(w.games))
+}
+
+// NewReport groups the games by platform and sums them up.
+func NewReport(games []domain.Game) Report {
+	byPlatform := map[string]*ReportPlatform{}
+	report := Report{}
+
+	for _, game := range games {
+		name := string(game.Platform)
+		if name == "" {
+			name = "Unknown platform"
+		}
+
+		platform, ok := byPlatform[name]
+		if !ok {
+			platform = &ReportPlatform{Name: name}
+			byPlatform[name] = platform
+		}
+
+		platform.Games = append(platform.Games, game)
+		platform.Total.add(game)
+		report.Total.add(game)
+	}
+
+	for _, platform := range byPlatform {
+		sort.SliceStable(platform.Games, func(i, j int) bool {
+			return strings.ToLower(platform.Games[i].Title) < strings.ToLower(platform.Games[j].Title)
+		})
+		report.Platforms = append(report.Platforms, *platform)
+	}
+
+	sort.Slice(report.Platforms, func(i, j int) bool {
+		return report.Platforms[i].Name < report.Platforms[j].Name
+	})
+
+	return report
+}
+
+func (t *ReportTotal) add(game domain.Game) {
+	t.Games++
+	t.Value += game.PricechartingValue
+
+	if game.Completeness.HasGame && game.Completeness.HasBox && game.Completeness.HasManual {
+		t.Complete++
+	}
+}
+
+// markdownAnchor returns the anchor GitHub and most wikis generate for a heading.
+func markdownAnchor(heading string) string {
+	anchor := nonAnchorPattern.ReplaceAllString(strings.ToLower(heading), "")
+	return strings.ReplaceAll(anchor, " ", "-")
+}
+
+// markdownCell escapes a value for a Markdown table cell, which cannot hold
+// pipes or line breaks.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// completenessText lists the parts of a game that are present, e.g. "Game, Box".
+func completenessText(completeness domain.Completeness) string {
+	parts := []string{}
+	if completeness.HasGame {
+		parts = append(parts, "Game")
+	}
+	if completeness.HasBox {
+		parts = append(parts, "Box")
+	}
+	if completeness.HasManual {
+		parts = append(parts, "Manual")
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,65 @@
+package write
+
+import (
+	"bytes"
+	"main/src/domain"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownWriter(t *testing.T) {
+	games := []domain.Game{
+		{Title: "Sonic the Hedgehog", Platform: domain.Genesis, Region: "USA", Condition: "Good", PricechartingValue: 12.5, Completeness: domain.Completeness{HasGame: true, HasBox: true, HasManual: true}},
+		{Title: "Super Mario Bros. 3", Platform: domain.NES, Edition: "Player's Choice | Rev A", PricechartingValue: 30, Completeness: domain.Completeness{HasGame: true}},
+		{Title: "8 Eyes", Platform: domain.NES, Completeness: domain.Completeness{HasGame: true, HasBox: true}},
+	}
+
+	var buffer bytes.Buffer
+	writer, err := NewGameWriter(FormatMarkdown, &buffer, FormatOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, game := range games {
+		writer.WriteGame(game)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	report := buffer.String()
+	for _, expected := range []string{
+		"3 games on 2 platforms, 1 complete, valued at $42.50.",
+		"- [Genesis / Mega Drive](#genesis--mega-drive) (1)\n- [NES](#nes) (2)\n",
+		"## Genesis / Mega Drive\n",
+		"| Sonic the Hedgehog | USA |  | Game, Box, Manual | Good | $12.50 |\n",
+		"| Super Mario Bros. 3 |  | Player's Choice \\| Rev A | Game |  | $30.00 |\n",
+		"| **Total** | | | 0 of 2 complete | | **$30.00** |\n",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("expected the report to contain %q, got\n%s", expected, report)
+		}
+	}
+
+	// games are sorted by title within their platform
+	if strings.Index(report, "| 8 Eyes |") > strings.Index(report, "| Super Mario Bros. 3 |") {
+		t.Errorf("expected the NES games to be sorted by title")
+	}
+}
+
+func TestMarkdownWriterTemplate(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := NewMarkdownWriter(&buffer, "{{range .Platforms}}* {{.Name}}: {{.Total.Games}}\n{{end}}")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	writer.WriteGame(domain.Game{Title: "Guardian Heroes", Platform: domain.Saturn})
+	writer.Close()
+
+	if buffer.String() != "* Saturn: 1\n" {
+		t.Errorf("expected the custom template output, got %q", buffer.String())
+	}
+
+	if _, err := NewMarkdownWriter(&buffer, "{{.Platforms"); err == nil {
+		t.Errorf("expected an error for an invalid template")
+	}
+}
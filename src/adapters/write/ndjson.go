@@ -0,0 +1,55 @@
+package write
+
+import (
+	"encoding/json"
+	"io"
+	"main/src/domain"
+)
+
+// NDJSONWriter writes games as newline-delimited JSON, one domain.Game object
+// per line. Every game is written with a single write and, when the writer
+// buffers its output, flushed, so readers see each game as soon as it is written.
+type NDJSONWriter struct {
+	writer io.Writer
+}
+
+// NewNDJSONWriter creates an NDJSONWriter writing to the provided writer.
+//
+// Parameters:
+//   - writer: The io.Writer the NDJSON data will be written to.
+//
+// Returns:
+//   - A pointer to an NDJSONWriter instance.
+func NewNDJSONWriter(writer io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{writer: writer}
+}
+
+// WriteGame writes a single game as one line of JSON.
+//
+// Parameters:
+//   - game: The domain.Game to write.
+//
+// Returns:
+//   - error: An error if marshalling, writing or flushing the game fails, otherwise nil.
+func (w *NDJSONWriter) WriteGame(game domain.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.writer.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if flusher, ok := w.writer.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+
+	return nil
+}
+
+// Close ends the NDJSON output, which needs no terminator. It does not close
+// the underlying writer.
+func (w *NDJSONWriter) Close() error {
+	return nil
+}
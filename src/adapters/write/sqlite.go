@@ -0,0 +1,379 @@
+package write
+
+import (
+	"database/sql"
+	"fmt"
+	"main/src/domain"
+	"net/url"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchemaVersion is the version of the database schema, recorded as the
+// user_version of the database. It is bumped whenever sqliteSchema changes.
+const sqliteSchemaVersion = 1
+
+// sqliteSchema is the normalized collection schema. Games are keyed by their
+// CLZ ID; platforms, genres and companies are shared lookup tables joined to
+// the games, and links, covers and prices belong to a single game.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS platforms (
+	id   INTEGER PRIMARY KEY,
+	name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS games (
+	clz_id             INTEGER PRIMARY KEY,
+	bpgameid           INTEGER,
+	igdb_id            INTEGER,
+	title              TEXT NOT NULL,
+	platform_id        INTEGER REFERENCES platforms (id),
+	edition            TEXT,
+	format             TEXT,
+	region             TEXT,
+	language           TEXT,
+	release_date       TEXT,
+	first_release_date TEXT,
+	series             TEXT,
+	summary            TEXT,
+	storyline          TEXT,
+	audience_rating    TEXT,
+	hardware_type      TEXT,
+	condition          TEXT,
+	has_box            INTEGER NOT NULL,
+	has_manual         INTEGER NOT NULL,
+	has_game           INTEGER NOT NULL,
+	boxset             INTEGER NOT NULL,
+	multiplayer        INTEGER NOT NULL,
+	quantity           INTEGER,
+	disk_count         INTEGER,
+	my_rating          REAL,
+	purchase_date      TEXT,
+	purchase_price     REAL,
+	store              TEXT,
+	location           TEXT,
+	owner              TEXT,
+	notes              TEXT,
+	upc                TEXT,
+	date_acquired      TEXT,
+	last_modified      TEXT
+);
+
+CREATE TABLE IF NOT EXISTS genres (
+	id   INTEGER PRIMARY KEY,
+	name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS game_genres (
+	game_id  INTEGER NOT NULL REFERENCES games (clz_id) ON DELETE CASCADE,
+	genre_id INTEGER NOT NULL REFERENCES genres (id),
+	PRIMARY KEY (game_id, genre_id)
+);
+
+CREATE TABLE IF NOT EXISTS companies (
+	id   INTEGER PRIMARY KEY,
+	name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS game_companies (
+	game_id    INTEGER NOT NULL REFERENCES games (clz_id) ON DELETE CASCADE,
+	company_id INTEGER NOT NULL REFERENCES companies (id),
+	role       TEXT NOT NULL CHECK (role IN ('developer', 'publisher')),
+	PRIMARY KEY (game_id, company_id, role)
+);
+
+CREATE TABLE IF NOT EXISTS links (
+	id          INTEGER PRIMARY KEY,
+	game_id     INTEGER NOT NULL REFERENCES games (clz_id) ON DELETE CASCADE,
+	description TEXT,
+	url         TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS covers (
+	game_id    INTEGER PRIMARY KEY REFERENCES games (clz_id) ON DELETE CASCADE,
+	igdb_id    INTEGER,
+	width      INTEGER,
+	url        TEXT,
+	local_path TEXT
+);
+
+CREATE TABLE IF NOT EXISTS prices (
+	game_id INTEGER PRIMARY KEY REFERENCES games (clz_id) ON DELETE CASCADE,
+	loose   REAL,
+	cib     REAL,
+	new     REAL,
+	value   REAL,
+	url     TEXT
+);
+
+CREATE INDEX IF NOT EXISTS games_platform_id ON games (platform_id);
+CREATE INDEX IF NOT EXISTS game_genres_genre_id ON game_genres (genre_id);
+CREATE INDEX IF NOT EXISTS game_companies_company_id ON game_companies (company_id);
+CREATE INDEX IF NOT EXISTS links_game_id ON links (game_id);
+`
+
+const upsertGame = `
+INSERT INTO games (
+	clz_id, bpgameid, igdb_id, title, platform_id, edition, format, region, language,
+	release_date, first_release_date, series, summary, storyline, audience_rating,
+	hardware_type, condition, has_box, has_manual, has_game, boxset, multiplayer,
+	quantity, disk_count, my_rating, purchase_date, purchase_price, store, location,
+	owner, notes, upc, date_acquired, last_modified
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (clz_id) DO UPDATE SET
+	bpgameid = excluded.bpgameid,
+	igdb_id = excluded.igdb_id,
+	title = excluded.title,
+	platform_id = excluded.platform_id,
+	edition = excluded.edition,
+	format = excluded.format,
+	region = excluded.region,
+	language = excluded.language,
+	release_date = excluded.release_date,
+	first_release_date = excluded.first_release_date,
+	series = excluded.series,
+	summary = excluded.summary,
+	storyline = excluded.storyline,
+	audience_rating = excluded.audience_rating,
+	hardware_type = excluded.hardware_type,
+	condition = excluded.condition,
+	has_box = excluded.has_box,
+	has_manual = excluded.has_manual,
+	has_game = excluded.has_game,
+	boxset = excluded.boxset,
+	multiplayer = excluded.multiplayer,
+	quantity = excluded.quantity,
+	disk_count = excluded.disk_count,
+	my_rating = excluded.my_rating,
+	purchase_date = excluded.purchase_date,
+	purchase_price = excluded.purchase_price,
+	store = excluded.store,
+	location = excluded.location,
+	owner = excluded.owner,
+	notes = excluded.notes,
+	upc = excluded.upc,
+	date_acquired = excluded.date_acquired,
+	last_modified = excluded.last_modified`
+
+// SQLiteWriter writes games to a SQLite database with a normalized collection
+// schema, upserting every game by its CLZ ID so that writing a collection to an
+// existing database updates its games instead of duplicating them. Games
+// missing from a later collection are kept. All games are written in a single
+// transaction, committed on Close.
+type SQLiteWriter struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+// NewSQLiteWriter opens the SQLite database, creating it and its schema if
+// needed, and starts the transaction the games are written in.
+//
+// Parameters:
+//   - filename: The path of the SQLite database file.
+//
+// Returns:
+//   - A pointer to a SQLiteWriter instance.
+//   - error: An error if the database cannot be opened or has an incompatible schema, otherwise nil.
+func NewSQLiteWriter(filename string) (*SQLiteWriter, error) {
+	db, err := sql.Open("sqlite", sqliteDSN(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteWriter{db: db, tx: tx}, nil
+}
+
+// sqliteDSN returns the URI the database file is opened with. The path is
+// escaped, so file names containing '?', '#' or '%' are not taken for the
+// query or fragment of the URI.
+func sqliteDSN(filename string) string {
+	dsn := url.URL{Scheme: "file", Path: filename, OmitHost: true, RawQuery: "_pragma=foreign_keys(1)"}
+	return dsn.String()
+}
+
+func migrateSQLite(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+
+	if version != 0 && version != sqliteSchemaVersion {
+		return fmt.Errorf("database schema version %d is not supported, expected %d", version, sqliteSchemaVersion)
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("error creating database schema: %w", err)
+	}
+
+	_, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", sqliteSchemaVersion))
+	return err
+}
+
+// WriteGame upserts a single game, replacing its genres, companies, links,
+// cover and prices.
+//
+// Parameters:
+//   - game: The domain.Game to write.
+//
+// Returns:
+//   - error: An error if the game has no CLZ ID or cannot be written, otherwise nil.
+func (w *SQLiteWriter) WriteGame(game domain.Game) error {
+	if game.CLZ_ID == 0 {
+		return fmt.Errorf("game %s (%s) has no CLZ ID", game.Title, game.Platform)
+	}
+
+	platformID, err := w.lookupID("platforms", string(game.Platform))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.tx.Exec(upsertGame,
+		game.CLZ_ID, nullInt(game.BPGameID), nullInt(game.IGDB_ID), game.Title, platformID,
+		nullText(game.Edition), nullText(game.Format), nullText(game.Region), nullText(game.Language),
+		nullText(game.ReleaseDate.String()), nullTime(game.FirstReleaseDate), nullText(game.Series),
+		nullText(game.Summary), nullText(game.Storyline), nullText(game.AudienceRating),
+		nullText(game.HardwareType), nullText(game.Condition), game.Completeness.HasBox,
+		game.Completeness.HasManual, game.Completeness.HasGame, game.Boxset, game.Multiplayer,
+		nullInt(game.Quantity), nullInt(game.DiskCount), nullFloat(game.MyRating),
+		nullText(game.PurchaseDate.String()), nullFloat(game.PurchasePrice), nullText(game.Store),
+		nullText(game.Location), nullText(game.Owner), nullText(game.Notes), nullText(game.UPC),
+		nullTime(game.DateAcquired), nullTime(game.LastModified),
+	)
+	if err != nil {
+		return fmt.Errorf("error writing game %d: %w", game.CLZ_ID, err)
+	}
+
+	for _, table := range []string{"game_genres", "game_companies", "links", "covers", "prices"} {
+		if _, err := w.tx.Exec("DELETE FROM "+table+" WHERE game_id = ?", game.CLZ_ID); err != nil {
+			return fmt.Errorf("error clearing %s of game %d: %w", table, game.CLZ_ID, err)
+		}
+	}
+
+	for _, genre := range game.Genres {
+		genreID, err := w.lookupID("genres", genre)
+		if err != nil {
+			return err
+		}
+		if _, err := w.tx.Exec("INSERT OR IGNORE INTO game_genres (game_id, genre_id) VALUES (?, ?)", game.CLZ_ID, genreID); err != nil {
+			return fmt.Errorf("error writing genres of game %d: %w", game.CLZ_ID, err)
+		}
+	}
+
+	companies := map[string][]string{"developer": game.Developers, "publisher": game.Publishers}
+	for _, role := range []string{"developer", "publisher"} {
+		for _, company := range companies[role] {
+			companyID, err := w.lookupID("companies", company)
+			if err != nil {
+				return err
+			}
+			if _, err := w.tx.Exec("INSERT OR IGNORE INTO game_companies (game_id, company_id, role) VALUES (?, ?, ?)", game.CLZ_ID, companyID, role); err != nil {
+				return fmt.Errorf("error writing companies of game %d: %w", game.CLZ_ID, err)
+			}
+		}
+	}
+
+	for _, link := range game.Links {
+		if _, err := w.tx.Exec("INSERT INTO links (game_id, description, url) VALUES (?, ?, ?)", game.CLZ_ID, nullText(link.Description), link.URL); err != nil {
+			return fmt.Errorf("error writing links of game %d: %w", game.CLZ_ID, err)
+		}
+	}
+
+	if game.Cover != (domain.Cover{}) {
+		_, err := w.tx.Exec("INSERT INTO covers (game_id, igdb_id, width, url, local_path) VALUES (?, ?, ?, ?, ?)",
+			game.CLZ_ID, nullInt(game.Cover.ID), nullInt(game.Cover.Width), nullText(game.Cover.URL), nullText(game.Cover.LocalPath))
+		if err != nil {
+			return fmt.Errorf("error writing cover of game %d: %w", game.CLZ_ID, err)
+		}
+	}
+
+	if game.PricechartingLoose != 0 || game.PricechartingCIB != 0 || game.PricechartingNew != 0 || game.PricechartingValue != 0 || game.PricechartingURL != "" {
+		_, err := w.tx.Exec("INSERT INTO prices (game_id, loose, cib, new, value, url) VALUES (?, ?, ?, ?, ?, ?)",
+			game.CLZ_ID, nullFloat(game.PricechartingLoose), nullFloat(game.PricechartingCIB), nullFloat(game.PricechartingNew),
+			nullFloat(game.PricechartingValue), nullText(game.PricechartingURL))
+		if err != nil {
+			return fmt.Errorf("error writing prices of game %d: %w", game.CLZ_ID, err)
+		}
+	}
+
+	return nil
+}
+
+// lookupID returns the ID of the named row of a lookup table, inserting the row if needed.
+func (w *SQLiteWriter) lookupID(table string, name string) (sql.NullInt64, error) {
+	if name == "" {
+		return sql.NullInt64{}, nil
+	}
+
+	var id sql.NullInt64
+	err := w.tx.QueryRow("INSERT INTO "+table+" (name) VALUES (?) ON CONFLICT (name) DO UPDATE SET name = excluded.name RETURNING id", name).Scan(&id)
+	if err != nil {
+		return sql.NullInt64{}, fmt.Errorf("error writing %s %q: %w", table, name, err)
+	}
+
+	return id, nil
+}
+
+// Close removes platforms, genres and companies no game refers to any more, commits the
+// games and closes the database.
+//
+// Returns:
+//   - error: An error if committing fails, otherwise nil.
+func (w *SQLiteWriter) Close() error {
+	defer w.db.Close()
+
+	for _, statement := range []string{
+		"DELETE FROM platforms WHERE id NOT IN (SELECT platform_id FROM games WHERE platform_id IS NOT NULL)",
+		"DELETE FROM genres WHERE id NOT IN (SELECT genre_id FROM game_genres)",
+		"DELETE FROM companies WHERE id NOT IN (SELECT company_id FROM game_companies)",
+	} {
+		if _, err := w.tx.Exec(statement); err != nil {
+			w.tx.Rollback()
+			return err
+		}
+	}
+
+	return w.tx.Commit()
+}
+
+// Discard rolls back the games written so far and closes the database.
+func (w *SQLiteWriter) Discard() error {
+	defer w.db.Close()
+
+	return w.tx.Rollback()
+}
+
+// nullText stores empty strings as NULL, as they are omitted from the JSON output.
+func nullText(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullInt stores unknown, zero, numbers as NULL, as they are omitted from the JSON output.
+func nullInt(value int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(value), Valid: value != 0}
+}
+
+// nullFloat stores unknown, zero, amounts as NULL, as they are omitted from the JSON output.
+func nullFloat(value float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: value, Valid: value != 0}
+}
+
+// nullTime stores timestamps as RFC 3339 and unknown timestamps as NULL.
+func nullTime(value time.Time) sql.NullString {
+	if value.IsZero() {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: value.Format(time.RFC3339), Valid: true}
+}
@@ -0,0 +1,177 @@
+package write
+
+import (
+	"database/sql"
+	"main/src/domain"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSQLiteGames(t *testing.T, filename string, games ...domain.Game) {
+	t.Helper()
+
+	writer, err := NewSQLiteWriter(filename)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, game := range games {
+		if err := writer.WriteGame(game); err != nil {
+			writer.Discard()
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func queryInt(t *testing.T, db *sql.DB, query string, args ...interface{}) int {
+	t.Helper()
+
+	var value int
+	if err := db.QueryRow(query, args...).Scan(&value); err != nil {
+		t.Fatalf("expected no error for %q, got %v", query, err)
+	}
+
+	return value
+}
+
+func TestSQLiteWriter(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "collection.sqlite")
+
+	mario := domain.Game{
+		CLZ_ID:             1,
+		Title:              "Super Mario Bros. 3",
+		Platform:           domain.NES,
+		Genres:             []string{"Platform", "Action"},
+		Developers:         []string{"Nintendo"},
+		Publishers:         []string{"Nintendo"},
+		Links:              []domain.Link{{Description: "Manual", URL: "https://example.com/manual"}},
+		Cover:              domain.Cover{ID: 136520, URL: "//images.igdb.com/igdb/image/upload/t_cover_big/co1j8f.jpg"},
+		PricechartingLoose: 20.5,
+		ReleaseDate:        domain.NewDate(1990, 2, 12),
+	}
+	tokobot := domain.Game{CLZ_ID: 2, Title: "Tokobot", Platform: domain.PSP, Genres: []string{"Puzzle"}}
+
+	writeSQLiteGames(t, filename, mario, tokobot)
+
+	// a repeated run updates the games in place
+	mario.Title = "Super Mario Bros. 3 (Rev A)"
+	mario.Genres = []string{"Platform"}
+	mario.Links = nil
+	mario.PricechartingLoose = 0
+	writeSQLiteGames(t, filename, mario)
+
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer db.Close()
+
+	if count := queryInt(t, db, "SELECT COUNT(*) FROM games"); count != 2 {
+		t.Errorf("expected 2 games, got %d", count)
+	}
+
+	var title, platform, releaseDate string
+	err = db.QueryRow("SELECT g.title, p.name, g.release_date FROM games g JOIN platforms p ON p.id = g.platform_id WHERE g.clz_id = 1").Scan(&title, &platform, &releaseDate)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if title != "Super Mario Bros. 3 (Rev A)" || platform != "NES" || releaseDate != "1990-02-12" {
+		t.Errorf("expected the updated game, got %s (%s) released %s", title, platform, releaseDate)
+	}
+
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"SELECT COUNT(*) FROM game_genres WHERE game_id = 1", 1},
+		{"SELECT COUNT(*) FROM game_genres WHERE game_id = 2", 1},
+		// the Action genre is no longer used by any game
+		{"SELECT COUNT(*) FROM genres", 2},
+		{"SELECT COUNT(*) FROM companies", 1},
+		{"SELECT COUNT(*) FROM game_companies WHERE game_id = 1", 2},
+		{"SELECT COUNT(*) FROM links", 0},
+		{"SELECT COUNT(*) FROM covers WHERE game_id = 1 AND igdb_id = 136520", 1},
+		{"SELECT COUNT(*) FROM prices", 0},
+		{"SELECT COUNT(*) FROM platforms", 2},
+	}
+
+	for _, tt := range tests {
+		if actual := queryInt(t, db, tt.query); actual != tt.expected {
+			t.Errorf("expected %q to be %d, got %d", tt.query, tt.expected, actual)
+		}
+	}
+
+	if version := queryInt(t, db, "PRAGMA user_version"); version != sqliteSchemaVersion {
+		t.Errorf("expected user_version %d, got %d", sqliteSchemaVersion, version)
+	}
+}
+
+func TestSQLiteWriterFilename(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer os.Chdir(wd)
+
+	tests := []struct {
+		name     string
+		filename string
+	}{
+		{name: "relative path", filename: "collection.sqlite"},
+		{name: "question mark", filename: filepath.Join(dir, "collection?.sqlite")},
+		{name: "hash", filename: filepath.Join(dir, "collection #2.sqlite")},
+		{name: "percent", filename: filepath.Join(dir, "100%25 collection.sqlite")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeSQLiteGames(t, tt.filename, domain.Game{CLZ_ID: 1, Title: "Guardian Heroes", Platform: domain.Saturn})
+
+			if _, err := os.Stat(filepath.Join(dir, filepath.Base(tt.filename))); err != nil {
+				t.Fatalf("expected the database to be written to %q, got %v", tt.filename, err)
+			}
+
+			db, err := sql.Open("sqlite", sqliteDSN(tt.filename))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			defer db.Close()
+
+			if count := queryInt(t, db, "SELECT COUNT(*) FROM games"); count != 1 {
+				t.Errorf("expected 1 game, got %d", count)
+			}
+			if enabled := queryInt(t, db, "PRAGMA foreign_keys"); enabled != 1 {
+				t.Errorf("expected foreign keys to be enabled, got %d", enabled)
+			}
+		})
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(entries) != len(tests) {
+		t.Errorf("expected %d database files, got %d", len(tests), len(entries))
+	}
+}
+
+func TestSQLiteWriterRequiresCLZID(t *testing.T) {
+	writer, err := NewSQLiteWriter(filepath.Join(t.TempDir(), "collection.sqlite"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer writer.Discard()
+
+	if err := writer.WriteGame(domain.Game{Title: "Guardian Heroes", Platform: domain.Saturn}); err == nil {
+		t.Errorf("expected an error for a game without a CLZ ID")
+	}
+}
@@ -0,0 +1,257 @@
+package write
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"main/src/domain"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultListDelimiter joins the values of multi-valued columns when no
+// delimiter is configured.
+const DefaultListDelimiter = "; "
+
+// DefaultColumns are the columns written when no columns are configured.
+var DefaultColumns = []string{
+	"clz_id",
+	"title",
+	"platform",
+	"edition",
+	"region",
+	"release_date",
+	"genres",
+	"developers",
+	"publishers",
+	"condition",
+	"has_box",
+	"has_manual",
+	"has_game",
+	"purchase_date",
+	"purchase_price",
+	"pricecharting_value",
+	"location",
+	"links",
+}
+
+// column formats a single field of a game as a table cell. Multi-valued fields
+// are joined with the list delimiter.
+type column func(game domain.Game, listDelimiter string) string
+
+// columns are the available table columns, named after the JSON keys of the
+// fields they hold.
+var columns = map[string]column{
+	"audience_rating":     textColumn(func(g domain.Game) string { return g.AudienceRating }),
+	"boxset":              boolColumn(func(g domain.Game) bool { return g.Boxset }),
+	"bpgameid":            intColumn(func(g domain.Game) int { return g.BPGameID }),
+	"clz_id":              intColumn(func(g domain.Game) int { return g.CLZ_ID }),
+	"condition":           textColumn(func(g domain.Game) string { return g.Condition }),
+	"cover_local_path":    textColumn(func(g domain.Game) string { return g.Cover.LocalPath }),
+	"cover_url":           textColumn(func(g domain.Game) string { return g.Cover.URL }),
+	"date_acquired":       timeColumn(func(g domain.Game) time.Time { return g.DateAcquired }),
+	"developers":          listColumn(func(g domain.Game) []string { return g.Developers }),
+	"disk_count":          intColumn(func(g domain.Game) int { return g.DiskCount }),
+	"edition":             textColumn(func(g domain.Game) string { return g.Edition }),
+	"first_release_date":  timeColumn(func(g domain.Game) time.Time { return g.FirstReleaseDate }),
+	"format":              textColumn(func(g domain.Game) string { return g.Format }),
+	"franchises":          listColumn(func(g domain.Game) []string { return g.Franchises }),
+	"genres":              listColumn(func(g domain.Game) []string { return g.Genres }),
+	"hardware_type":       textColumn(func(g domain.Game) string { return g.HardwareType }),
+	"has_box":             boolColumn(func(g domain.Game) bool { return g.Completeness.HasBox }),
+	"has_game":            boolColumn(func(g domain.Game) bool { return g.Completeness.HasGame }),
+	"has_manual":          boolColumn(func(g domain.Game) bool { return g.Completeness.HasManual }),
+	"igdb_id":             intColumn(func(g domain.Game) int { return g.IGDB_ID }),
+	"language":            textColumn(func(g domain.Game) string { return g.Language }),
+	"last_modified":       timeColumn(func(g domain.Game) time.Time { return g.LastModified }),
+	"links":               listColumn(linkURLs),
+	"location":            textColumn(func(g domain.Game) string { return g.Location }),
+	"multiplayer":         boolColumn(func(g domain.Game) bool { return g.Multiplayer }),
+	"my_rating":           floatColumn(func(g domain.Game) float64 { return g.MyRating }),
+	"notes":               textColumn(func(g domain.Game) string { return g.Notes }),
+	"owner":               textColumn(func(g domain.Game) string { return g.Owner }),
+	"platform":            textColumn(func(g domain.Game) string { return string(g.Platform) }),
+	"pricecharting_cib":   floatColumn(func(g domain.Game) float64 { return g.PricechartingCIB }),
+	"pricecharting_loose": floatColumn(func(g domain.Game) float64 { return g.PricechartingLoose }),
+	"pricecharting_new":   floatColumn(func(g domain.Game) float64 { return g.PricechartingNew }),
+	"pricecharting_url":   textColumn(func(g domain.Game) string { return g.PricechartingURL }),
+	"pricecharting_value": floatColumn(func(g domain.Game) float64 { return g.PricechartingValue }),
+	"publishers":          listColumn(func(g domain.Game) []string { return g.Publishers }),
+	"purchase_date":       textColumn(func(g domain.Game) string { return g.PurchaseDate.String() }),
+	"purchase_price":      floatColumn(func(g domain.Game) float64 { return g.PurchasePrice }),
+	"quantity":            intColumn(func(g domain.Game) int { return g.Quantity }),
+	"region":              textColumn(func(g domain.Game) string { return g.Region }),
+	"release_date":        textColumn(func(g domain.Game) string { return g.ReleaseDate.String() }),
+	"series":              textColumn(func(g domain.Game) string { return g.Series }),
+	"storyline":           textColumn(func(g domain.Game) string { return g.Storyline }),
+	"store":               textColumn(func(g domain.Game) string { return g.Store }),
+	"summary":             textColumn(func(g domain.Game) string { return g.Summary }),
+	"tags":                listColumn(func(g domain.Game) []string { return g.Tags }),
+	"title":               textColumn(func(g domain.Game) string { return g.Title }),
+	"upc":                 textColumn(func(g domain.Game) string { return g.UPC }),
+}
+
+// Columns returns the names of the available table columns, sorted.
+func Columns() []string {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// TableOptions configures the CSV and TSV output.
+//
+// Fields:
+//   - Columns: The columns to write, in order, DefaultColumns when empty.
+//   - ListDelimiter: The delimiter joining multi-valued columns, DefaultListDelimiter when empty.
+type TableOptions struct {
+	Columns       []string
+	ListDelimiter string
+}
+
+// TableWriter writes games as rows of a CSV or TSV table, preceded by a header
+// row of the column names. Cells are quoted as needed by encoding/csv.
+type TableWriter struct {
+	writer        *csv.Writer
+	columns       []string
+	listDelimiter string
+	wroteHeader   bool
+}
+
+// NewTableWriter creates a TableWriter separating cells with the separator.
+//
+// Parameters:
+//   - writer: The io.Writer the table will be written to.
+//   - separator: The cell separator, ',' for CSV or '\t' for TSV.
+//   - options: The columns and list delimiter of the table.
+//
+// Returns:
+//   - A pointer to a TableWriter instance.
+//   - error: An error if a column is unknown, otherwise nil.
+func NewTableWriter(writer io.Writer, separator rune, options TableOptions) (*TableWriter, error) {
+	selected := options.Columns
+	if len(selected) == 0 {
+		selected = DefaultColumns
+	}
+
+	for _, name := range selected {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q, expected any of %s", name, strings.Join(Columns(), ", "))
+		}
+	}
+
+	listDelimiter := options.ListDelimiter
+	if listDelimiter == "" {
+		listDelimiter = DefaultListDelimiter
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = separator
+
+	return &TableWriter{writer: csvWriter, columns: selected, listDelimiter: listDelimiter}, nil
+}
+
+// WriteGame writes a single game as a row of the table.
+//
+// Parameters:
+//   - game: The domain.Game to write.
+//
+// Returns:
+//   - error: An error if writing the row fails, otherwise nil.
+func (w *TableWriter) WriteGame(game domain.Game) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	row := make([]string, len(w.columns))
+	for i, name := range w.columns {
+		row[i] = columns[name](game, w.listDelimiter)
+	}
+
+	return w.writer.Write(row)
+}
+
+// Close writes the header of an empty table and flushes the table. It does
+// not close the underlying writer.
+//
+// Returns:
+//   - error: An error if writing fails, otherwise nil.
+func (w *TableWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *TableWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+
+	w.wroteHeader = true
+	return w.writer.Write(w.columns)
+}
+
+func textColumn(value func(domain.Game) string) column {
+	return func(game domain.Game, _ string) string {
+		return value(game)
+	}
+}
+
+func listColumn(values func(domain.Game) []string) column {
+	return func(game domain.Game, listDelimiter string) string {
+		return strings.Join(values(game), listDelimiter)
+	}
+}
+
+func boolColumn(value func(domain.Game) bool) column {
+	return func(game domain.Game, _ string) string {
+		return strconv.FormatBool(value(game))
+	}
+}
+
+// intColumn leaves unknown, zero, values empty, as they are omitted from the JSON output.
+func intColumn(value func(domain.Game) int) column {
+	return func(game domain.Game, _ string) string {
+		if v := value(game); v != 0 {
+			return strconv.Itoa(v)
+		}
+		return ""
+	}
+}
+
+// floatColumn leaves unknown, zero, values empty, as they are omitted from the JSON output.
+func floatColumn(value func(domain.Game) float64) column {
+	return func(game domain.Game, _ string) string {
+		if v := value(game); v != 0 {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return ""
+	}
+}
+
+// timeColumn formats timestamps as RFC 3339 and leaves unknown timestamps empty.
+func timeColumn(value func(domain.Game) time.Time) column {
+	return func(game domain.Game, _ string) string {
+		if v := value(game); !v.IsZero() {
+			return v.Format(time.RFC3339)
+		}
+		return ""
+	}
+}
+
+func linkURLs(game domain.Game) []string {
+	urls := make([]string, 0, len(game.Links))
+	for _, link := range game.Links {
+		urls = append(urls, link.URL)
+	}
+
+	return urls
+}
@@ -1,7 +1,12 @@
 package write
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"main/src/domain"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -26,3 +31,161 @@ func TestWriteFile(t *testing.T) {
 
 	os.Remove(filename)
 }
+
+func TestJSONCollectionWriter(t *testing.T) {
+	games := []domain.Game{
+		{Title: "Super Mario Bros. 3", Platform: "NES"},
+		{Title: "Guardian Heroes", Platform: "Saturn"},
+	}
+
+	for _, count := range []int{0, 1, 2} {
+		var buffer bytes.Buffer
+		writer := NewJSONCollectionWriter(&buffer)
+
+		for _, game := range games[:count] {
+			if err := writer.WriteGame(game); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected, _ := json.Marshal(domain.GameCollection{SchemaVersion: domain.SchemaVersion, Games: append([]domain.Game{}, games[:count]...)})
+		if buffer.String() != string(expected) {
+			t.Errorf("expected %s, got %s", string(expected), buffer.String())
+		}
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	games := []domain.Game{
+		{Title: "Super Mario Bros. 3", Platform: "NES"},
+		{Title: "Guardian Heroes", Platform: "Saturn"},
+	}
+
+	var buffer bytes.Buffer
+	buffered := bufio.NewWriterSize(&buffer, 4096)
+	writer, err := NewGameWriter(FormatNDJSON, buffered, FormatOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for i, game := range games {
+		if err := writer.WriteGame(game); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// every game is flushed as soon as it is written
+		lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+		if len(lines) != i+1 {
+			t.Fatalf("expected %d lines after writing game %d, got %q", i+1, i+1, buffer.String())
+		}
+
+		var decoded domain.Game
+		if err := json.Unmarshal([]byte(lines[i]), &decoded); err != nil {
+			t.Fatalf("expected line %d to be a JSON game, got %v", i+1, err)
+		}
+		if decoded.Title != game.Title || decoded.Platform != game.Platform {
+			t.Errorf("expected %s (%s), got %s (%s)", game.Title, game.Platform, decoded.Title, decoded.Platform)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasSuffix(buffer.String(), "}\n") {
+		t.Errorf("expected output to end with a newline terminated game, got %q", buffer.String())
+	}
+
+	if _, err := NewGameWriter("xml", &buffer, FormatOptions{}); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
+
+func TestTableWriter(t *testing.T) {
+	games := []domain.Game{
+		{
+			CLZ_ID:        7,
+			Title:         `Sonic "Blue Blur", Deluxe`,
+			Platform:      domain.Genesis,
+			Genres:        []string{"Platform", "Action"},
+			Links:         []domain.Link{{Description: "Manual", URL: "https://example.com/manual"}, {URL: "https://example.com/box"}},
+			PurchasePrice: 12.5,
+			ReleaseDate:   domain.NewDate(1991, 6, 0),
+		},
+		{CLZ_ID: 8, Title: "Guardian Heroes", Platform: domain.Saturn},
+	}
+	options := TableOptions{Columns: []string{"title", "platform", "genres", "links", "purchase_price", "release_date", "clz_id"}, ListDelimiter: " | "}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			FormatCSV,
+			"title,platform,genres,links,purchase_price,release_date,clz_id\n" +
+				`"Sonic ""Blue Blur"", Deluxe",Genesis / Mega Drive,Platform | Action,https://example.com/manual | https://example.com/box,12.5,1991-06,7` + "\n" +
+				"Guardian Heroes,Saturn,,,,,8\n",
+		},
+		{
+			FormatTSV,
+			"title\tplatform\tgenres\tlinks\tpurchase_price\trelease_date\tclz_id\n" +
+				"\"Sonic \"\"Blue Blur\"\", Deluxe\"\tGenesis / Mega Drive\tPlatform | Action\thttps://example.com/manual | https://example.com/box\t12.5\t1991-06\t7\n" +
+				"Guardian Heroes\tSaturn\t\t\t\t\t8\n",
+		},
+	}
+
+	for _, tt := range tests {
+		var buffer bytes.Buffer
+		writer, err := NewGameWriter(tt.format, &buffer, FormatOptions{Table: options})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for _, game := range games {
+			if err := writer.WriteGame(game); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if buffer.String() != tt.expected {
+			t.Errorf("expected %s output\n%s\ngot\n%s", tt.format, tt.expected, buffer.String())
+		}
+	}
+}
+
+func TestTableWriterDefaults(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := NewTableWriter(&buffer, ',', TableOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := writer.WriteGame(domain.Game{Title: "Tokobot", Genres: []string{"Action", "Puzzle"}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	writer.Close()
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if lines[0] != strings.Join(DefaultColumns, ",") {
+		t.Errorf("expected the default columns header, got %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "Action; Puzzle") {
+		t.Errorf("expected genres joined with the default delimiter, got %s", lines[1])
+	}
+
+	// an empty table still has its header
+	buffer.Reset()
+	writer, _ = NewTableWriter(&buffer, '\t', TableOptions{Columns: []string{"title"}})
+	writer.Close()
+	if buffer.String() != "title\n" {
+		t.Errorf("expected only the header, got %q", buffer.String())
+	}
+
+	if _, err := NewTableWriter(&buffer, ',', TableOptions{Columns: []string{"title", "rating"}}); err == nil {
+		t.Errorf("expected an error for an unknown column")
+	}
+}
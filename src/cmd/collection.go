@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"main/src/domain"
+	"os"
+)
+
+// readCollection reads a collection translated to JSON. Collections written
+// with another schema version are rejected, as their fields would only be
+// partly decoded.
+func readCollection(filename string) (domain.GameCollection, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return domain.GameCollection{}, fmt.Errorf("error reading translated data: %w", err)
+	}
+
+	var collection domain.GameCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return domain.GameCollection{}, fmt.Errorf("error decoding translated data: %w", err)
+	}
+
+	if collection.SchemaVersion != domain.SchemaVersion {
+		return domain.GameCollection{}, fmt.Errorf("translated data %s has schema version %d, expected %d: re-run translate to update it",
+			filename, collection.SchemaVersion, domain.SchemaVersion)
+	}
+
+	return collection, nil
+}
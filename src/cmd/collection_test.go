@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadCollectionSchemaVersion(t *testing.T) {
+	tests := []struct {
+		data        string
+		expectedErr string
+	}{
+		{`{"schema_version":1,"games":[{"clz_id":812,"title":"1Xtreme","pricecharting_value":5.97}]}`, ""},
+		// output written before the schema was versioned used the Go field names
+		{`{"Games":[{"CLZ_ID":812,"Title":"1Xtreme","PricechartingValue":5.97}]}`, "schema version 0, expected 1: re-run translate"},
+		{`{"schema_version":2,"games":[]}`, "schema version 2, expected 1"},
+	}
+
+	for _, tt := range tests {
+		filename := filepath.Join(t.TempDir(), "games.json")
+		os.WriteFile(filename, []byte(tt.data), 0644)
+
+		collection, err := readCollection(filename)
+		if tt.expectedErr == "" {
+			if err != nil || len(collection.Games) != 1 || collection.Games[0].PricechartingValue != 5.97 {
+				t.Errorf("expected the collection to be read, got %+v, %v", collection, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+			t.Errorf("expected an error containing %q, got %v", tt.expectedErr, err)
+		}
+	}
+}
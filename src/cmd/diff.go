@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"main/src/domain"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat string
+
+	diffCmd = &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Compare two snapshots of a collection",
+		Long: "Compares two CLZ collection XML exports or two JSON collections written by translate and reports the " +
+			"added, removed and modified games, with the changed fields of each modified game such as its condition, " +
+			"completeness, quantity and PriceCharting value. Games are matched by CLZ ID, otherwise by bpgameid.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if diffFormat != "text" && diffFormat != "json" {
+				return fmt.Errorf("unknown diff format %q, expected text or json", diffFormat)
+			}
+
+			oldGames, err := readGames(args[0])
+			if err != nil {
+				return err
+			}
+			newGames, err := readGames(args[1])
+			if err != nil {
+				return err
+			}
+
+			diff, err := domain.DiffGames(oldGames, newGames)
+			if err != nil {
+				return fmt.Errorf("error comparing collections: %w", err)
+			}
+
+			if diffFormat == "json" {
+				data, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return fmt.Errorf("error marshalling diff JSON: %w", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return nil
+			}
+
+			writeDiffText(cmd.OutOrStdout(), diff)
+			return nil
+		},
+	}
+)
+
+// writeDiffText writes the diff as lines of added (+), removed (-) and
+// modified (~) games, each change of a modified game indented below it.
+func writeDiffText(out io.Writer, diff domain.CollectionDiff) {
+	if diff.Empty() {
+		fmt.Fprintln(out, "No differences")
+		return
+	}
+
+	fmt.Fprintf(out, "%d added, %d removed, %d modified\n", len(diff.Added), len(diff.Removed), len(diff.Modified))
+
+	for _, game := range diff.Added {
+		fmt.Fprintf(out, "+ %s (%s) [%s]\n", game.Title, game.Platform, game.Key)
+	}
+	for _, game := range diff.Removed {
+		fmt.Fprintf(out, "- %s (%s) [%s]\n", game.Title, game.Platform, game.Key)
+	}
+	for _, game := range diff.Modified {
+		fmt.Fprintf(out, "~ %s (%s) [%s]\n", game.Title, game.Platform, game.Key)
+		for _, change := range game.Changes {
+			fmt.Fprintf(out, "    %s: %s -> %s\n", change.Field, diffValue(change.Old), diffValue(change.New))
+		}
+	}
+}
+
+// diffValue formats a changed value as JSON, so strings are quoted and empty values read as null.
+func diffValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "output format (text, json)")
+	rootCmd.AddCommand(diffCmd)
+}
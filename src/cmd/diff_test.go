@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"main/src/domain"
+	"strings"
+	"testing"
+)
+
+func TestWriteDiffText(t *testing.T) {
+	diff := domain.CollectionDiff{
+		Added:   []domain.GameRef{{Key: "clz:2", Title: "Tokobot", Platform: domain.PSP}},
+		Removed: []domain.GameRef{{Key: "clz:3", Title: "8 Eyes", Platform: domain.NES}},
+		Modified: []domain.GameChanges{{
+			GameRef: domain.GameRef{Key: "clz:1", Title: "Super Mario Bros. 3", Platform: domain.NES},
+			Changes: []domain.FieldChange{
+				{Field: "condition", Old: "Good", New: "Mint"},
+				{Field: "pricecharting_value", Old: 12.5, New: nil},
+			},
+		}},
+	}
+
+	var output bytes.Buffer
+	writeDiffText(&output, diff)
+
+	expected := "1 added, 1 removed, 1 modified\n" +
+		"+ Tokobot (PSP) [clz:2]\n" +
+		"- 8 Eyes (NES) [clz:3]\n" +
+		"~ Super Mario Bros. 3 (NES) [clz:1]\n" +
+		"    condition: \"Good\" -> \"Mint\"\n" +
+		"    pricecharting_value: 12.5 -> null\n"
+	if output.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, output.String())
+	}
+
+	output.Reset()
+	writeDiffText(&output, domain.CollectionDiff{})
+	if !strings.HasPrefix(output.String(), "No differences") {
+		t.Errorf("expected no differences, got %s", output.String())
+	}
+}
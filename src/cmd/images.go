@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"main/src/adapters/images"
+	"main/src/adapters/write"
+	"main/src/domain"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	imagesSourceFile    string
+	imagesWriteFileName string
+	imagesDir           string
+	imagesSize          string
+	imagesArtworks      bool
+	imagesScreenshots   bool
+
+	imagesCmd = &cobra.Command{
+		Use:   "images",
+		Short: "Download the IGDB images of a translated collection and reference the local files",
+		Long: "Downloads the IGDB covers, and optionally artworks and screenshots, of a collection translated with -i " +
+			"into a content-addressed image directory and records the local path of every image in the JSON output. " +
+			"Images already downloaded by an earlier run are not downloaded again.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if imagesSourceFile == "" {
+				return errors.New("source file is required")
+			}
+
+			if !images.ValidSize(imagesSize) {
+				return fmt.Errorf("unknown image size %q, expected one of %s", imagesSize, strings.Join(images.Sizes, ", "))
+			}
+
+			collection, err := readCollection(imagesSourceFile)
+			if err != nil {
+				return err
+			}
+
+			store, err := images.NewStore(imagesDir, nil)
+			if err != nil {
+				return fmt.Errorf("error opening image directory: %w", err)
+			}
+
+			options := images.Options{Size: imagesSize, Artworks: imagesArtworks, Screenshots: imagesScreenshots}
+
+			// a failed download only affects its own game, so every game is
+			// attempted and the output written before the failures are surfaced
+			failed := 0
+			for i := range collection.Games {
+				game := &collection.Games[i]
+				if err := store.LocalizeGame(game, options); err != nil {
+					failed++
+					fmt.Printf("error downloading images for %s (%s): %v\n", game.Title, game.Platform, err)
+				}
+			}
+
+			if err := store.SaveIndex(); err != nil {
+				return fmt.Errorf("error writing image index: %w", err)
+			}
+
+			outputFile := imagesSourceFile
+			if imagesWriteFileName != "" {
+				outputFile = imagesWriteFileName + ".json"
+			}
+
+			// the collection is rewritten with the current domain types
+			collection.SchemaVersion = domain.SchemaVersion
+			jsonData, err := json.Marshal(collection)
+			if err != nil {
+				return fmt.Errorf("error marshalling translated data JSON: %w", err)
+			}
+
+			if err := write.WriteFile(jsonData, outputFile); err != nil {
+				return fmt.Errorf("error writing to file: %w", err)
+			}
+			fmt.Printf("JSON data with local image paths written to file: %s \n", outputFile)
+
+			if failed > 0 {
+				return fmt.Errorf("images of %d game(s) could not be downloaded", failed)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	imagesCmd.Flags().StringVarP(&imagesSourceFile, "sourceFile", "s", "", "translated JSON data file to download the images of")
+	imagesCmd.Flags().StringVarP(&imagesWriteFileName, "writeFileName", "w", "", "filename to write the JSON data to (defaults to updating the source file)")
+	imagesCmd.Flags().StringVar(&imagesDir, "images-dir", "images", "directory to store the downloaded images in")
+	imagesCmd.Flags().StringVar(&imagesSize, "size", images.DefaultSize, "IGDB image size preset ("+strings.Join(images.Sizes, ", ")+")")
+	imagesCmd.Flags().BoolVar(&imagesArtworks, "artworks", false, "also download the artworks of every game")
+	imagesCmd.Flags().BoolVar(&imagesScreenshots, "screenshots", false, "also download the screenshots of every game")
+	rootCmd.AddCommand(imagesCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"main/src/adapters/write"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportSourceFile    string
+	reportWriteFileName string
+	reportTemplateFile  string
+
+	reportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Render a Markdown report of a translated collection",
+		Long: "Renders a Markdown report of a translated JSON collection with a table of contents and a table of games " +
+			"per platform with totals. The layout is a Go text/template that can be replaced with --template.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if reportSourceFile == "" {
+				return errors.New("source file is required")
+			}
+
+			collection, err := readCollection(reportSourceFile)
+			if err != nil {
+				return err
+			}
+
+			options, err := formatOptions(nil, "", reportTemplateFile)
+			if err != nil {
+				return err
+			}
+
+			var buffer bytes.Buffer
+			markdownWriter, err := write.NewMarkdownWriter(&buffer, options.MarkdownTemplate)
+			if err != nil {
+				return fmt.Errorf("error parsing report template: %w", err)
+			}
+
+			for _, game := range collection.Games {
+				markdownWriter.WriteGame(game)
+			}
+			if err := markdownWriter.Close(); err != nil {
+				return fmt.Errorf("error rendering report: %w", err)
+			}
+
+			if reportWriteFileName == "" {
+				fmt.Print(buffer.String())
+				return nil
+			}
+
+			if err := write.WriteFile(buffer.Bytes(), reportWriteFileName+".md"); err != nil {
+				return fmt.Errorf("error writing to file: %w", err)
+			}
+			fmt.Printf("Markdown report written to file: %s.md \n", reportWriteFileName)
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	reportCmd.Flags().StringVarP(&reportSourceFile, "sourceFile", "s", "", "translated JSON data file to report on")
+	reportCmd.Flags().StringVarP(&reportWriteFileName, "writeFileName", "w", "", "filename to write the report to, without extension (defaults to printing it)")
+	reportCmd.Flags().StringVar(&reportTemplateFile, "template", "", "text/template file replacing the default report layout")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"main/src/adapters/igdb"
+	"main/src/adapters/overrides"
+	"main/src/domain"
+	clz_translate "main/src/domain/clz-translation"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewSeedFile       string
+	reviewOverridesFile  string
+	reviewThreshold      float64
+	reviewCandidateCount int
+	reviewCacheDir       string
+
+	reviewMatchesCmd = &cobra.Command{
+		Use:   "review-matches",
+		Short: "Interactively review ambiguous IGDB matches and save the decisions as overrides",
+		Long: "Walks the games of a CLZ collection whose best IGDB match has a low confidence, shows the top IGDB candidates " +
+			"and lets you pick one, skip the game, mark it as never to be enriched, or enter an IGDB ID manually. " +
+			"Decisions are saved to the overrides file used by later translate runs.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if reviewSeedFile == "" {
+				return errors.New("seed file is required")
+			}
+			reviewOverridesFile = stringFlagOrDefault(cmd, "overrides-file", reviewOverridesFile, defaultOverridesFile)
+			reviewCacheDir = stringFlagOrDefault(cmd, "cache-dir", reviewCacheDir, defaultIGDBCacheDir)
+
+			input, err := os.Open(reviewSeedFile)
+			if err != nil {
+				return fmt.Errorf("error reading CLZ data: %w", err)
+			}
+			defer input.Close()
+
+			var games []domain.Game
+			for game, err := range clz_translate.StreamCLZ(input) {
+				if err != nil {
+					return err
+				}
+				games = append(games, game)
+			}
+
+			matchOverrides, err := overrides.LoadFile(reviewOverridesFile)
+			if err != nil {
+				return fmt.Errorf("error reading IGDB overrides file: %w", err)
+			}
+
+			adapterInit := igdb.IGDBAdapterInitFromEnv()
+			adapterInit.CacheDir = reviewCacheDir
+			reviewer, err := newMatchReviewer(adapterInit, cmd.InOrStdin(), cmd.OutOrStdout(), func(updated domain.MatchOverrides) error {
+				return overrides.SaveFile(reviewOverridesFile, updated)
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = reviewer.review(games, matchOverrides)
+			return err
+		},
+	}
+)
+
+// errReviewQuit stops the review when the user asks to quit.
+var errReviewQuit = errors.New("review quit")
+
+// matchReviewer walks games with low confidence IGDB matches and records the
+// user's decisions as match overrides.
+type matchReviewer struct {
+	rank       func(domain.Game) ([]igdb.MatchCandidate, error)
+	threshold  float64
+	candidates int
+	in         *bufio.Scanner
+	out        io.Writer
+	save       func(domain.MatchOverrides) error
+}
+
+// newMatchReviewer returns a matchReviewer ranking candidates with an IGDB
+// adapter, configured by the review-matches flags.
+//
+// Parameters:
+//   - adapterInit: The IGDB client configuration.
+//   - in: The reader the decisions are read from.
+//   - out: The writer the games and candidates are shown on.
+//   - save: The function saving the overrides after each decision.
+//
+// Returns:
+//   - The matchReviewer.
+//   - error: An *igdb.AuthError if no IGDB access token could be obtained, otherwise nil.
+func newMatchReviewer(adapterInit igdb.IGDBAdapterInit, in io.Reader, out io.Writer, save func(domain.MatchOverrides) error) (matchReviewer, error) {
+	igdbAdapter, err := igdb.NewIGDBAdapter(adapterInit)
+	if err != nil {
+		return matchReviewer{}, err
+	}
+
+	return matchReviewer{
+		rank:       igdbAdapter.RankGameCandidates,
+		threshold:  reviewThreshold,
+		candidates: reviewCandidateCount,
+		in:         bufio.NewScanner(in),
+		out:        out,
+		save:       save,
+	}, nil
+}
+
+// review prompts for every game without an override whose best candidate is
+// below the review threshold, saving the overrides after each decision so an
+// interrupted review keeps its progress.
+func (r matchReviewer) review(games []domain.Game, matchOverrides domain.MatchOverrides) (domain.MatchOverrides, error) {
+	reviewed := 0
+
+	for i, game := range games {
+		if _, ok := matchOverrides.Find(game); ok {
+			continue
+		}
+
+		candidates, err := r.rank(game)
+		if err != nil {
+			fmt.Fprintf(r.out, "error searching IGDB for %s: %v\n", game.Title, err)
+			continue
+		}
+
+		if len(candidates) > 0 && candidates[0].Confidence >= r.threshold {
+			continue
+		}
+
+		reviewed++
+		override, decided, err := r.prompt(i, len(games), game, candidates)
+		if errors.Is(err, errReviewQuit) {
+			break
+		}
+		if err != nil {
+			return matchOverrides, err
+		}
+		if !decided {
+			continue
+		}
+
+		matchOverrides = matchOverrides.Set(override)
+		if err := r.save(matchOverrides); err != nil {
+			return matchOverrides, fmt.Errorf("error saving IGDB overrides file: %w", err)
+		}
+	}
+
+	fmt.Fprintf(r.out, "reviewed %d game(s)\n", reviewed)
+	return matchOverrides, nil
+}
+
+func (r matchReviewer) prompt(index int, total int, game domain.Game, candidates []igdb.MatchCandidate) (domain.MatchOverride, bool, error) {
+	override := domain.MatchOverride{CLZ_ID: game.CLZ_ID}
+	if game.CLZ_ID == 0 {
+		override = domain.MatchOverride{Title: game.Title, Platform: game.Platform}
+	}
+
+	if len(candidates) > r.candidates {
+		candidates = candidates[:r.candidates]
+	}
+
+	fmt.Fprintf(r.out, "\n[%d/%d] %s (%s", index+1, total, game.Title, game.Platform)
+	if !game.ReleaseDate.IsZero() {
+		fmt.Fprintf(r.out, ", %d", game.ReleaseDate.Year())
+	}
+	fmt.Fprintln(r.out, ")")
+
+	if len(candidates) == 0 {
+		fmt.Fprintln(r.out, "  no IGDB candidates found")
+	}
+	for i, candidate := range candidates {
+		year := "unknown"
+		if candidate.ReleaseYear != 0 {
+			year = strconv.Itoa(candidate.ReleaseYear)
+		}
+		fmt.Fprintf(r.out, "  %d) %s [%s] %s (id %d, confidence %.2f)\n", i+1, candidate.Name, platformNames(candidate.Platforms), year, candidate.ID, candidate.Confidence)
+	}
+
+	for {
+		fmt.Fprintf(r.out, "choose 1-%d, (s)kip, (n)ever enrich, (i)d, (q)uit: ", len(candidates))
+		if !r.in.Scan() {
+			if err := r.in.Err(); err != nil {
+				return override, false, err
+			}
+			return override, false, errReviewQuit
+		}
+
+		answer := strings.ToLower(strings.TrimSpace(r.in.Text()))
+		switch answer {
+		case "s", "skip", "":
+			return override, false, nil
+		case "n", "never":
+			override.Skip = true
+			return override, true, nil
+		case "q", "quit":
+			return override, false, errReviewQuit
+		case "i", "id":
+			fmt.Fprint(r.out, "IGDB ID: ")
+			if !r.in.Scan() {
+				return override, false, errReviewQuit
+			}
+			id, err := strconv.Atoi(strings.TrimSpace(r.in.Text()))
+			if err != nil || id <= 0 {
+				fmt.Fprintln(r.out, "invalid IGDB ID")
+				continue
+			}
+			override.IGDB_ID = id
+			return override, true, nil
+		}
+
+		choice, err := strconv.Atoi(answer)
+		if err != nil || choice < 1 || choice > len(candidates) {
+			fmt.Fprintln(r.out, "invalid choice")
+			continue
+		}
+
+		override.IGDB_ID = candidates[choice-1].ID
+		return override, true, nil
+	}
+}
+
+func platformNames(platformIDs []int) string {
+	names := []string{}
+	for _, id := range platformIDs {
+		name, ok := domain.PlatformMap.IGDBToCLZ[id]
+		if !ok {
+			name = "#" + strconv.Itoa(id)
+		}
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	reviewMatchesCmd.Flags().StringVarP(&reviewSeedFile, "seedFile", "s", "", "seed data file to review (CLZ collection XML export)")
+	reviewMatchesCmd.Flags().StringVar(&reviewOverridesFile, "overrides-file", "", "JSON file to save IGDB match overrides to (default $IGDB_OVERRIDES_FILE or igdb-overrides.json)")
+	reviewMatchesCmd.Flags().Float64Var(&reviewThreshold, "review-threshold", 0.8, "review games whose best IGDB match confidence (0-1) is below this value")
+	reviewMatchesCmd.Flags().IntVar(&reviewCandidateCount, "candidates", 5, "number of IGDB candidates to show per game")
+	reviewMatchesCmd.Flags().StringVar(&reviewCacheDir, "cache-dir", "", "directory to cache IGDB responses and the Twitch access token in, empty to disable caching (default $IGDB_CACHE_DIR or the user cache directory)")
+	rootCmd.AddCommand(reviewMatchesCmd)
+}
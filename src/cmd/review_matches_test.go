@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"main/src/_test/mocks"
+	"main/src/adapters/igdb"
+	"main/src/adapters/overrides"
+	"main/src/domain"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchReviewer(t *testing.T) {
+	games := []domain.Game{
+		{CLZ_ID: 1, Title: "Super Mario Bros. 3", Platform: "NES"},
+		{CLZ_ID: 2, Title: "Guardian Heroes", Platform: "Saturn"},
+		{CLZ_ID: 3, Title: "Tokobot", Platform: "PSP"},
+		{CLZ_ID: 4, Title: "NiGHTS into Dreams", Platform: "Saturn"},
+		{CLZ_ID: 5, Title: "Tomb Raider", Platform: "Saturn"},
+		{CLZ_ID: 6, Title: "8 Eyes", Platform: "NES"},
+	}
+	candidates := map[string][]igdb.MatchCandidate{
+		"Super Mario Bros. 3": {{ID: 1068, Name: "Super Mario Bros. 3", Platforms: []int{18}, Confidence: 0.95}},
+		"Guardian Heroes":     {{ID: 10, Name: "Guardian Heroes", Platforms: []int{32}, Confidence: 0.7}, {ID: 11, Name: "Guardian Heroes Advance", Confidence: 0.5}},
+		"Tokobot":             {{ID: 20, Name: "Tokobot Plus", Confidence: 0.4}},
+		"NiGHTS into Dreams":  {{ID: 30, Name: "NiGHTS", Confidence: 0.5}},
+		"Tomb Raider":         {{ID: 40, Name: "Tomb Raider", Confidence: 0.5}},
+	}
+
+	var saved domain.MatchOverrides
+	var output bytes.Buffer
+	reviewer := matchReviewer{
+		rank: func(game domain.Game) ([]igdb.MatchCandidate, error) {
+			return candidates[game.Title], nil
+		},
+		threshold:  0.8,
+		candidates: 5,
+		// pick the second candidate, never enrich, enter an ID after an invalid choice, skip, quit
+		in:  bufio.NewScanner(strings.NewReader("2\nn\n9\ni\n1234\ns\nq\n")),
+		out: &output,
+		save: func(updated domain.MatchOverrides) error {
+			saved = append(domain.MatchOverrides{}, updated...)
+			return nil
+		},
+	}
+
+	result, err := reviewer.review(games, domain.MatchOverrides{{CLZ_ID: 5, IGDB_ID: 40}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := domain.MatchOverrides{
+		{CLZ_ID: 5, IGDB_ID: 40},
+		{CLZ_ID: 2, IGDB_ID: 11},
+		{CLZ_ID: 3, Skip: true},
+		{CLZ_ID: 4, IGDB_ID: 1234},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+
+	if !reflect.DeepEqual(saved, expected) {
+		t.Errorf("expected saved overrides %+v, got %+v", expected, saved)
+	}
+
+	if strings.Contains(output.String(), "Super Mario Bros. 3 (NES") {
+		t.Errorf("expected confident match not to be reviewed, got output:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "invalid choice") {
+		t.Errorf("expected invalid choice to be reported, got output:\n%s", output.String())
+	}
+}
+
+func TestNewMatchReviewer(t *testing.T) {
+	authServer := mocks.GetTestTwitchAuthServer()
+	defer authServer.Close()
+	igdbServer := mocks.GetTestIGDBServer()
+	defer igdbServer.Close()
+
+	var saved domain.MatchOverrides
+	var output bytes.Buffer
+	reviewer, err := newMatchReviewer(igdb.IGDBAdapterInit{
+		AuthBaseUrl:      authServer.URL,
+		AuthUrlPath:      "/oauth2/token",
+		AuthClientId:     "test_client_id",
+		AuthClientSecret: "test_client_secret",
+		IGDBBaseUrl:      igdbServer.URL,
+	}, strings.NewReader("1\n"), &output, func(updated domain.MatchOverrides) error {
+		saved = append(domain.MatchOverrides{}, updated...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	reviewer.threshold = 0.8
+	reviewer.candidates = 5
+
+	// the mocked search results match Super Mario Bros. 3 exactly, but The
+	// Legend of Zelda only to an unrelated candidate, picked as the first choice
+	games := []domain.Game{
+		{CLZ_ID: 1, Title: "Super Mario Bros. 3", Platform: "NES"},
+		{CLZ_ID: 2, Title: "The Legend of Zelda", Platform: "NES"},
+	}
+	result, err := reviewer.review(games, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v\n%s", err, output.String())
+	}
+
+	expected := domain.MatchOverrides{{CLZ_ID: 2, IGDB_ID: 3}}
+	if !reflect.DeepEqual(result, expected) || !reflect.DeepEqual(saved, expected) {
+		t.Errorf("expected %+v to be saved, got %+v and %+v\noutput:\n%s", expected, result, saved, output.String())
+	}
+
+	if !strings.Contains(output.String(), "[2/2] The Legend of Zelda (NES)") || strings.Contains(output.String(), "[1/2]") {
+		t.Errorf("expected only The Legend of Zelda to be reviewed, got output:\n%s", output.String())
+	}
+}
+
+func TestReviewMatchesEnvDefaults(t *testing.T) {
+	authServer := mocks.GetTestTwitchAuthServer()
+	defer authServer.Close()
+	igdbServer := mocks.GetTestIGDBServer()
+	defer igdbServer.Close()
+
+	t.Setenv("IGDB_AUTH_BASE_URL", authServer.URL)
+	t.Setenv("IGDB_AUTH_PATH", "/oauth2/token")
+	t.Setenv("IGDB_CLIENT_ID", "test_client_id")
+	t.Setenv("IGDB_CLIENT_SECRET", "test_client_secret")
+	t.Setenv("IGDB_BASE_URL", igdbServer.URL)
+	t.Setenv("IGDB_API_RATE_LIMIT", "0")
+
+	// the defaults are set after the flags are registered, like the variables
+	// loaded from .env.local
+	dir := t.TempDir()
+	overridesFilename := filepath.Join(dir, "overrides.json")
+	cacheDirname := filepath.Join(dir, "cache")
+	t.Setenv("IGDB_OVERRIDES_FILE", overridesFilename)
+	t.Setenv("IGDB_CACHE_DIR", cacheDirname)
+
+	seed := filepath.Join(dir, "games.xml")
+	if err := os.WriteFile(seed, []byte("<gamelist><game><id>2</id><title>The Legend of Zelda</title><platform><displayname>NES</displayname></platform></game></gamelist>"), 0644); err != nil {
+		t.Fatalf("error writing seed file: %v", err)
+	}
+
+	defer func(seed string) { reviewSeedFile = seed }(reviewSeedFile)
+	reviewSeedFile = seed
+
+	var output bytes.Buffer
+	reviewMatchesCmd.SetIn(strings.NewReader("1\n"))
+	reviewMatchesCmd.SetOut(&output)
+	defer reviewMatchesCmd.SetIn(nil)
+	defer reviewMatchesCmd.SetOut(nil)
+
+	if err := reviewMatchesCmd.RunE(reviewMatchesCmd, nil); err != nil {
+		t.Fatalf("expected no error, got %v\n%s", err, output.String())
+	}
+
+	saved, err := overrides.LoadFile(overridesFilename)
+	if err != nil {
+		t.Fatalf("error reading overrides file: %v", err)
+	}
+	expected := domain.MatchOverrides{{CLZ_ID: 2, IGDB_ID: 3}}
+	if !reflect.DeepEqual(saved, expected) {
+		t.Errorf("expected %+v to be saved to IGDB_OVERRIDES_FILE, got %+v", expected, saved)
+	}
+
+	if _, err := os.Stat(cacheDirname); err != nil {
+		t.Errorf("expected IGDB responses to be cached in IGDB_CACHE_DIR, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"main/src/domain"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -10,12 +11,42 @@ var rootCmd = &cobra.Command{
 	Use:   "CLZTranslate",
 	Short: "A translation tool for CLZ game collection XML data to JSON",
 	Long:  "This tool translates CLZ game collection data in XML format to JSON format with optional IGDB supplement.",
+
+	// errors are reported by cobra, usage is only useful for flag mistakes
+	SilenceUsage: true,
+
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if platformsFile == "" {
+			return nil
+		}
+
+		return domain.LoadPlatformExtensionFile(platformsFile)
+	},
 }
 
+var platformsFile string
+
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// stringFlagOrDefault returns the value of the named flag if it was set on the
+// command line, otherwise its default. Defaults are resolved when the command
+// runs rather than when its flags are registered, so that they take the
+// environment variables loaded from .env.local into account.
+func stringFlagOrDefault(cmd *cobra.Command, name string, value string, defaultValue func() string) string {
+	if cmd.Flags().Changed(name) {
+		return value
+	}
+
+	return defaultValue()
+}
+
 func init() {
-	domain.LoadEnv(".env.local")
+	// the env file only holds IGDB settings, which not every command needs
+	if _, err := os.Stat(".env.local"); err == nil {
+		domain.LoadEnv(".env.local")
+	}
+
+	rootCmd.PersistentFlags().StringVar(&platformsFile, "platforms-file", os.Getenv("CLZ_PLATFORMS_FILE"), "JSON file of additional or corrected CLZ to IGDB platform mappings")
 }
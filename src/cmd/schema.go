@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"main/src/adapters/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the translated JSON output",
+	Long: "Prints the JSON Schema of the JSON written by translate, generated from the domain types. " +
+		"The schema_version of the output is bumped whenever the schema changes.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := schema.Generate()
+		if err != nil {
+			return fmt.Errorf("error generating JSON Schema: %w", err)
+		}
+
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"main/src/adapters/site"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	siteSourceFile   string
+	siteOutputDir    string
+	siteTemplatesDir string
+	siteTitle        string
+
+	siteCmd = &cobra.Command{
+		Use:   "site",
+		Short: "Generate a static HTML catalogue of a translated collection",
+		Long: "Generates a browsable static website from a translated JSON collection: index pages by platform, genre " +
+			"and series, a page per game and a search box. The site uses relative links only, so it can be opened " +
+			"from the file system without a server. Templates and assets in --templates-dir replace the defaults of the same name.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if siteSourceFile == "" {
+				return errors.New("source file is required")
+			}
+
+			collection, err := readCollection(siteSourceFile)
+			if err != nil {
+				return err
+			}
+
+			err = site.Generate(collection, siteOutputDir, site.Options{Title: siteTitle, TemplatesDir: siteTemplatesDir})
+			if err != nil {
+				return fmt.Errorf("error generating site: %w", err)
+			}
+
+			fmt.Printf("site of %d games written to: %s \n", len(collection.Games), siteOutputDir)
+			return nil
+		},
+	}
+)
+
+func init() {
+	siteCmd.Flags().StringVarP(&siteSourceFile, "sourceFile", "s", "", "translated JSON data file to generate the site from")
+	siteCmd.Flags().StringVarP(&siteOutputDir, "output-dir", "o", "site", "directory to write the site to")
+	siteCmd.Flags().StringVar(&siteTemplatesDir, "templates-dir", "", "directory of templates and assets overriding the defaults (layout.html, index.html, group.html, game.html, style.css, search.js)")
+	siteCmd.Flags().StringVar(&siteTitle, "title", site.DefaultTitle, "title of the site")
+	rootCmd.AddCommand(siteCmd)
+}
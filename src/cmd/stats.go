@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"main/src/domain"
+	clz_translate "main/src/domain/clz-translation"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSourceFile string
+	statsFormat     string
+
+	statsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Print statistics of a CLZ export or translated collection",
+		Long: "Prints the game counts per platform, region, format and genre, the completeness of the games, their " +
+			"PriceCharting value, the games acquired per month and how many games have IGDB data, as a table or as JSON. " +
+			"The source is either a CLZ collection XML export or a JSON collection written by translate.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if statsSourceFile == "" {
+				return errors.New("source file is required")
+			}
+			if statsFormat != "table" && statsFormat != "json" {
+				return fmt.Errorf("unknown stats format %q, expected table or json", statsFormat)
+			}
+
+			games, err := readGames(statsSourceFile)
+			if err != nil {
+				return err
+			}
+
+			stats := domain.ComputeStats(games)
+
+			if statsFormat == "json" {
+				data, err := json.MarshalIndent(stats, "", "  ")
+				if err != nil {
+					return fmt.Errorf("error marshalling stats JSON: %w", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return nil
+			}
+
+			return writeStatsTable(cmd.OutOrStdout(), stats)
+		},
+	}
+)
+
+// readGames reads the games of a CLZ collection XML export, translated
+// without IGDB data, or of a JSON collection written by translate.
+func readGames(filename string) ([]domain.Game, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading source file: %w", err)
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		collection, err := readCollection(filename)
+		return collection.Games, err
+	}
+
+	games := []domain.Game{}
+	for game, err := range clz_translate.StreamCLZ(bytes.NewReader(data)) {
+		if err != nil {
+			return nil, fmt.Errorf("error reading CLZ data: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// writeStatsTable writes the stats as aligned tables, one per aggregate.
+func writeStatsTable(out io.Writer, stats domain.Stats) error {
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	percent := func(count int) string {
+		if stats.Games == 0 {
+			return "0%"
+		}
+		return strconv.FormatFloat(float64(count)*100/float64(stats.Games), 'f', 1, 64) + "%"
+	}
+
+	fmt.Fprintf(table, "Games\t%d\n", stats.Games)
+	fmt.Fprintf(table, "Value\t$%.2f\n", stats.Value)
+
+	groups := []struct {
+		title  string
+		groups []domain.GroupStats
+	}{
+		{"Platform", stats.Platforms},
+		{"Region", stats.Regions},
+		{"Format", stats.Formats},
+		{"Genre", stats.Genres},
+	}
+	for _, grouping := range groups {
+		fmt.Fprintf(table, "\n%s\tGames\tShare\tValue\n", grouping.title)
+		for _, group := range grouping.groups {
+			fmt.Fprintf(table, "%s\t%d\t%s\t$%.2f\n", group.Name, group.Games, percent(group.Games), group.Value)
+		}
+	}
+
+	fmt.Fprintf(table, "\nCompleteness\tGames\tShare\n")
+	fmt.Fprintf(table, "Complete in box\t%d\t%s\n", stats.Completeness.CompleteInBox, percent(stats.Completeness.CompleteInBox))
+	fmt.Fprintf(table, "Loose\t%d\t%s\n", stats.Completeness.Loose, percent(stats.Completeness.Loose))
+	fmt.Fprintf(table, "Partial\t%d\t%s\n", stats.Completeness.Partial, percent(stats.Completeness.Partial))
+
+	fmt.Fprintf(table, "\nAcquired\tGames\n")
+	for _, month := range stats.Acquisitions {
+		fmt.Fprintf(table, "%s\t%d\n", month.Month, month.Games)
+	}
+	fmt.Fprintf(table, "Unknown\t%d\n", stats.AcquisitionUnknown)
+
+	fmt.Fprintf(table, "\nIGDB data\tGames\tShare\n")
+	fmt.Fprintf(table, "IGDB ID\t%d\t%s\n", stats.Enrichment.IGDBIDs, percent(stats.Enrichment.IGDBIDs))
+	fmt.Fprintf(table, "Cover\t%d\t%s\n", stats.Enrichment.Covers, percent(stats.Enrichment.Covers))
+	fmt.Fprintf(table, "Summary\t%d\t%s\n", stats.Enrichment.Summaries, percent(stats.Enrichment.Summaries))
+
+	return table.Flush()
+}
+
+func init() {
+	statsCmd.Flags().StringVarP(&statsSourceFile, "sourceFile", "s", "", "CLZ collection XML export or translated JSON data file")
+	statsCmd.Flags().StringVar(&statsFormat, "format", "table", "output format (table, json)")
+	rootCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"main/src/domain"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadGames(t *testing.T) {
+	xmlGames, err := readGames("../_test/data/game-data-list.xml")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(xmlGames) != 8 {
+		t.Fatalf("expected 8 games from the CLZ export, got %d", len(xmlGames))
+	}
+
+	data, _ := json.Marshal(domain.GameCollection{SchemaVersion: domain.SchemaVersion, Games: xmlGames})
+	jsonFile := filepath.Join(t.TempDir(), "games.json")
+	os.WriteFile(jsonFile, data, 0644)
+
+	jsonGames, err := readGames(jsonFile)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(jsonGames) != len(xmlGames) || jsonGames[0].Title != xmlGames[0].Title {
+		t.Errorf("expected the translated collection to hold the same games")
+	}
+}
+
+func TestWriteStatsTable(t *testing.T) {
+	stats := domain.ComputeStats([]domain.Game{
+		{Title: "Super Mario Bros. 3", Platform: domain.NES, Completeness: domain.Completeness{HasGame: true, HasBox: true, HasManual: true}, PricechartingValue: 30},
+		{Title: "8 Eyes", Platform: domain.NES, Completeness: domain.Completeness{HasGame: true}, PricechartingValue: 12.5},
+	})
+
+	var output bytes.Buffer
+	if err := writeStatsTable(&output, stats); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, expected := range []string{"Value  $42.50", "NES       2      100.0%  $42.50", "Complete in box  1      50.0%", "Loose            1      50.0%", "IGDB ID    0      0.0%"} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("expected the table to contain %q, got\n%s", expected, output.String())
+		}
+	}
+}
@@ -1,11 +1,21 @@
 package cmd
 
 import (
-	"encoding/json"
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+	"main/src/adapters/igdb"
+	"main/src/adapters/images"
+	"main/src/adapters/overrides"
 	"main/src/adapters/write"
+	"main/src/domain"
 	clz_translate "main/src/domain/clz-translation"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -14,50 +24,337 @@ var (
 	seedFile       string
 	writeFileName  string
 	igdbSupplement bool
+	cacheDir       string
+	cacheTTL       time.Duration
+	refreshCache   bool
+	matchThreshold float64
+	overridesFile  string
+	clzImageDirs   []string
+	assetsDir      string
+	outputFormat   string
+	outputOptions  write.FormatOptions
+	tableColumns   []string
+	listDelimiter  string
+	reportTemplate string
 
 	translateCmd = &cobra.Command{
 		Use:   "translate",
 		Short: "Translate provided CLZ game collection data in XML format to JSON",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("attempt a games data translation...")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// progress is written to standard error, so the translated data stays
+			// parseable when it is written to standard output without an output file
+			progress := cmd.ErrOrStderr()
+			if writeFileName == "" && outputFormat == write.FormatSQLite {
+				return errors.New("write file name is required for the sqlite format")
+			}
+
+			fmt.Fprintln(progress, "attempt a games data translation...")
 
 			if seedFile == "" {
-				fmt.Println("seed file is required")
-				return
+				return errors.New("seed file is required")
 			}
+			overridesFile = stringFlagOrDefault(cmd, "overrides-file", overridesFile, defaultOverridesFile)
+			cacheDir = stringFlagOrDefault(cmd, "cache-dir", cacheDir, defaultIGDBCacheDir)
 
-			data, err := os.ReadFile(seedFile)
+			// the format and columns are checked before any output file is created
+			options, err := formatOptions(tableColumns, listDelimiter, reportTemplate)
 			if err != nil {
-				fmt.Printf("error reading CLZ data: %v", err)
-				return
+				return err
+			}
+			outputOptions = options
+
+			if outputFormat != write.FormatSQLite {
+				if _, err := write.NewGameWriter(outputFormat, io.Discard, outputOptions); err != nil {
+					return err
+				}
+			}
+			outputFile := writeFileName + "." + write.Extension(outputFormat)
+
+			writeOutput := func(games iter.Seq2[domain.Game, error]) error {
+				if writeFileName == "" {
+					return writeGamesTo(cmd.OutOrStdout(), games)
+				}
+				if err := writeGames(outputFile, games); err != nil {
+					return err
+				}
+				fmt.Fprintf(progress, "translated data written to file: %s \n", outputFile)
+				return nil
+			}
+
+			importer, err := newCLZImageImporter()
+			if err != nil {
+				return err
+			}
+
+			if !igdbSupplement {
+				failedImports, err := streamTranslation(seedFile, importer, progress, writeOutput)
+				if err != nil {
+					return fmt.Errorf("error streaming translated data: %w", err)
+				}
+				return clzImageImportError(failedImports)
+			}
+
+			input, err := os.Open(seedFile)
+			if err != nil {
+				return fmt.Errorf("error reading CLZ data: %w", err)
+			}
+			defer input.Close()
+
+			matchOverrides, err := overrides.LoadFile(overridesFile)
+			if err != nil {
+				return fmt.Errorf("error reading IGDB overrides file: %w", err)
 			}
 
-			translated := clz_translate.TranslateCLZ(string(data), igdbSupplement)
+			// games are written as soon as their chunk is supplemented with IGDB
+			// data. A diagnostics report still comes with a usable collection, so
+			// it is only surfaced as a failure once the output is written
+			var translateErr, fatalErr error
+			failedImports := 0
+			games := func(yield func(domain.Game, error) bool) {
+				stopped := false
+				_, translateErr = clz_translate.TranslateCLZ(input, clz_translate.TranslateOptions{
+					IGDBSupplement:     igdbSupplement,
+					IGDBCacheDir:       cacheDir,
+					IGDBCacheTTL:       cacheTTL,
+					RefreshIGDBCache:   refreshCache,
+					IGDBMatchThreshold: &matchThreshold,
+					IGDBOverrides:      matchOverrides,
+					OnGame: func(game domain.Game) bool {
+						// CLZ images are imported after enrichment so the front cover is
+						// only used as the cover of games without an IGDB cover
+						if !importCLZImages(progress, importer, &game) {
+							failedImports++
+						}
 
-			if writeFileName != "" {
-				jsonData, marshalErr := json.Marshal(translated)
-				if marshalErr != nil {
-					fmt.Printf("error marshalling translated data JSON: %v", err)
-					return
+						stopped = !yield(game, nil)
+						return !stopped
+					},
+					OnWarning: func(diagnostic clz_translate.GameDiagnostic) {
+						fmt.Fprintf(progress, "warning: %v\n", diagnostic)
+					},
+					Output: progress,
+				})
+
+				var report *clz_translate.DiagnosticsReport
+				if translateErr != nil && !errors.As(translateErr, &report) && !stopped {
+					fatalErr = translateErr
+					yield(domain.Game{}, fatalErr)
 				}
+			}
 
-				writeErr := write.WriteFile(jsonData, writeFileName+".json")
-				if writeErr != nil {
-					fmt.Printf("error writing to file: %v", err)
-					return
+			if err := writeOutput(games); err != nil {
+				if fatalErr != nil {
+					return fatalErr
 				}
-				fmt.Printf("translated JSON data written to file: %s.json \n", writeFileName)
-			} else {
-				fmt.Println("no filename provided, skipping write to file...")
-				fmt.Printf("translated JSON data: %#v \n", translated)
+				return fmt.Errorf("error writing translated data: %w", err)
 			}
+
+			return errors.Join(translateErr, clzImageImportError(failedImports))
 		},
 	}
 )
 
+// streamTranslation translates the CLZ XML seed file game by game, handing each
+// translated game to the output as soon as it has been decoded and its CLZ
+// images have been imported, printing failed imports to progress. It returns
+// the number of games whose CLZ images could not all be imported.
+func streamTranslation(seedFile string, importer *images.CLZImporter, progress io.Writer, output func(iter.Seq2[domain.Game, error]) error) (int, error) {
+	input, err := os.Open(seedFile)
+	if err != nil {
+		return 0, fmt.Errorf("error reading CLZ data: %w", err)
+	}
+	defer input.Close()
+
+	failedImports := 0
+	games := func(yield func(domain.Game, error) bool) {
+		for game, err := range clz_translate.StreamCLZ(input) {
+			if err == nil && !importCLZImages(progress, importer, &game) {
+				failedImports++
+			}
+
+			if !yield(game, err) || err != nil {
+				return
+			}
+		}
+	}
+
+	return failedImports, output(games)
+}
+
+// writeGames writes the games to the output file in the --format output format.
+// A SQLite database is updated in place, any other output file is replaced. The
+// first error yielded by the games stops the write and is returned; a SQLite
+// database is then left as it was, rather than committing a partial run.
+func writeGames(outputFile string, games iter.Seq2[domain.Game, error]) error {
+	if outputFormat == write.FormatSQLite {
+		sqliteWriter, err := write.NewSQLiteWriter(outputFile)
+		if err != nil {
+			return fmt.Errorf("error opening database: %w", err)
+		}
+
+		for game, err := range games {
+			if err == nil {
+				err = sqliteWriter.WriteGame(game)
+			}
+			if err != nil {
+				sqliteWriter.Discard()
+				return err
+			}
+		}
+
+		return sqliteWriter.Close()
+	}
+
+	// the first game is awaited before the output file is replaced, so a
+	// translation failing up front leaves an existing output file untouched
+	next, stop := iter.Pull2(games)
+	defer stop()
+
+	game, err, ok := next()
+	if err != nil {
+		return err
+	}
+
+	output, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer output.Close()
+
+	return writeGamesTo(output, func(yield func(domain.Game, error) bool) {
+		for ; ok; game, err, ok = next() {
+			if !yield(game, err) {
+				return
+			}
+		}
+	})
+}
+
+// writeGamesTo writes the games to the output in the --format output format,
+// stopping at the first error yielded by the games.
+func writeGamesTo(output io.Writer, games iter.Seq2[domain.Game, error]) error {
+	writer := bufio.NewWriter(output)
+	gameWriter, err := write.NewGameWriter(outputFormat, writer, outputOptions)
+	if err != nil {
+		return err
+	}
+
+	for game, err := range games {
+		if err == nil {
+			err = gameWriter.WriteGame(game)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := gameWriter.Close(); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// formatOptions returns the output format options of the table columns, list
+// delimiter and Markdown report template file, if any.
+func formatOptions(columns []string, delimiter string, templateFile string) (write.FormatOptions, error) {
+	options := write.FormatOptions{Table: write.TableOptions{Columns: columns, ListDelimiter: delimiter}}
+
+	if templateFile != "" {
+		source, err := os.ReadFile(templateFile)
+		if err != nil {
+			return write.FormatOptions{}, fmt.Errorf("error reading report template: %w", err)
+		}
+		options.MarkdownTemplate = string(source)
+	}
+
+	return options, nil
+}
+
+// newCLZImageImporter creates the importer for the CLZ images found through
+// the --clz-images mappings, nil when no mapping is given.
+func newCLZImageImporter() (*images.CLZImporter, error) {
+	if len(clzImageDirs) == 0 {
+		return nil, nil
+	}
+
+	mappings := make([]images.PathMapping, 0, len(clzImageDirs))
+	for _, value := range clzImageDirs {
+		mapping, err := images.ParsePathMapping(value)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	store, err := images.NewStore(assetsDir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error opening assets directory: %w", err)
+	}
+
+	return images.NewCLZImporter(store, mappings), nil
+}
+
+// importCLZImages imports the CLZ images of the game, printing the images that
+// could not be imported to progress. It reports whether every image was imported.
+func importCLZImages(progress io.Writer, importer *images.CLZImporter, game *domain.Game) bool {
+	if err := importer.ImportGame(game); err != nil {
+		fmt.Fprintf(progress, "error importing CLZ images for %s (%s): %v\n", game.Title, game.Platform, err)
+		return false
+	}
+
+	return true
+}
+
+// clzImageImportError returns an error if the CLZ images of any game could not
+// all be imported, so the command exits non-zero once the output is written.
+func clzImageImportError(failedImports int) error {
+	if failedImports > 0 {
+		return fmt.Errorf("CLZ images of %d game(s) could not be imported", failedImports)
+	}
+
+	return nil
+}
+
+// defaultIGDBCacheDir returns the IGDB_CACHE_DIR environment variable if set,
+// otherwise a directory within the user's cache directory.
+func defaultIGDBCacheDir() string {
+	if dir := os.Getenv("IGDB_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(userCacheDir, "clz-translate", "igdb")
+}
+
+// defaultOverridesFile returns the IGDB_OVERRIDES_FILE environment variable if
+// set, otherwise an overrides file in the working directory.
+func defaultOverridesFile() string {
+	if filename := os.Getenv("IGDB_OVERRIDES_FILE"); filename != "" {
+		return filename
+	}
+
+	return "igdb-overrides.json"
+}
+
 func init() {
 	translateCmd.Flags().StringVarP(&seedFile, "seedFile", "s", "", "seed data file to translate (CLZ collection XML export)")
-	translateCmd.Flags().StringVarP(&writeFileName, "writeFileName", "w", "", "filename to write JSON data to")
+	translateCmd.Flags().StringVarP(&writeFileName, "writeFileName", "w", "", "filename to write the translated data to, without extension (standard output when omitted)")
+	translateCmd.Flags().StringVar(&outputFormat, "format", write.FormatJSON, "output format ("+strings.Join(write.Formats, ", ")+"), ndjson writes one game per line")
+	translateCmd.Flags().StringSliceVar(&tableColumns, "columns", write.DefaultColumns, "comma-separated columns of the csv and tsv formats, in order (any of "+strings.Join(write.Columns(), ", ")+")")
+	translateCmd.Flags().StringVar(&reportTemplate, "report-template", "", "text/template file replacing the default layout of the markdown format")
+	translateCmd.Flags().StringVar(&listDelimiter, "list-delimiter", write.DefaultListDelimiter, "delimiter joining multi-valued columns such as genres in the csv and tsv formats")
 	translateCmd.Flags().BoolVarP(&igdbSupplement, "igdbSupplement", "i", false, "whether to supplement data with IGDB data")
+	translateCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "directory to cache IGDB responses and the Twitch access token in, empty to disable caching (default $IGDB_CACHE_DIR or the user cache directory)")
+	translateCmd.Flags().DurationVar(&cacheTTL, "cache-ttl", igdb.DefaultCacheTTL, "how long cached IGDB responses are reused for")
+	translateCmd.Flags().BoolVar(&refreshCache, "refresh-cache", false, "ignore cached IGDB responses and re-query IGDB")
+	translateCmd.Flags().Float64Var(&matchThreshold, "match-threshold", igdb.DefaultMatchThreshold, "minimum confidence (0-1) for an IGDB match, games below it are left unmatched, 0 accepts every best match")
+	translateCmd.Flags().StringVar(&overridesFile, "overrides-file", "", "JSON file of manual IGDB match overrides (default $IGDB_OVERRIDES_FILE or igdb-overrides.json)")
+	translateCmd.Flags().StringArrayVar(&clzImageDirs, "clz-images", nil, "WINDOWS_DIR=LOCAL_DIR mapping locating the images CLZ stored locally, repeatable")
+	translateCmd.Flags().StringVar(&assetsDir, "assets-dir", "assets", "directory to copy the CLZ images found through --clz-images to")
 	rootCmd.AddCommand(translateCmd)
 }
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"main/src/_test/mocks"
+	"main/src/adapters/write"
+	"main/src/domain"
+	clz_translate "main/src/domain/clz-translation"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gameSeq returns an iterator over already translated games.
+func gameSeq(games ...domain.Game) iter.Seq2[domain.Game, error] {
+	return func(yield func(domain.Game, error) bool) {
+		for _, game := range games {
+			if !yield(game, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestWriteGamesDiscardsSQLiteOnError(t *testing.T) {
+	defer func(format string) { outputFormat = format }(outputFormat)
+	outputFormat = write.FormatSQLite
+	filename := filepath.Join(t.TempDir(), "collection.sqlite")
+
+	mario := domain.Game{CLZ_ID: 1, Title: "Super Mario Bros. 3", Platform: domain.NES}
+	if err := writeGames(filename, gameSeq(mario)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// a decode error after the first game must not commit the partial run
+	decodeErr := errors.New("malformed CLZ XML")
+	games := func(yield func(domain.Game, error) bool) {
+		if yield(domain.Game{CLZ_ID: 2, Title: "Tokobot", Platform: domain.PSP}, nil) {
+			yield(domain.Game{}, decodeErr)
+		}
+	}
+	if err := writeGames(filename, games); !errors.Is(err, decodeErr) {
+		t.Fatalf("expected the decode error, got %v", err)
+	}
+
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM games").Scan(&count); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected only the game of the first run, got %d games", count)
+	}
+}
+
+func TestWriteGamesKeepsOutputFileOnEarlyError(t *testing.T) {
+	defer func(format string) { outputFormat = format }(outputFormat)
+	outputFormat = write.FormatNDJSON
+	filename := filepath.Join(t.TempDir(), "collection.ndjson")
+	os.WriteFile(filename, []byte("previous run\n"), 0644)
+
+	authErr := errors.New("no access token")
+	games := func(yield func(domain.Game, error) bool) {
+		yield(domain.Game{}, authErr)
+	}
+	if err := writeGames(filename, games); !errors.Is(err, authErr) {
+		t.Fatalf("expected the translation error, got %v", err)
+	}
+
+	if data, _ := os.ReadFile(filename); string(data) != "previous run\n" {
+		t.Errorf("expected the previous output to be kept, got %q", data)
+	}
+}
+
+func TestTranslateToStdout(t *testing.T) {
+	authServer := mocks.GetTestTwitchAuthServer()
+	defer authServer.Close()
+	igdbServer := mocks.GetTestIGDBServer()
+	defer igdbServer.Close()
+
+	t.Setenv("IGDB_AUTH_BASE_URL", authServer.URL)
+	t.Setenv("IGDB_AUTH_PATH", "/oauth2/token")
+	t.Setenv("IGDB_CLIENT_ID", "test_client_id")
+	t.Setenv("IGDB_CLIENT_SECRET", "test_client_secret")
+	t.Setenv("IGDB_BASE_URL", igdbServer.URL)
+	t.Setenv("IGDB_API_RATE_LIMIT", "0")
+	t.Setenv("IGDB_CACHE_DIR", t.TempDir())
+	t.Setenv("IGDB_OVERRIDES_FILE", filepath.Join(t.TempDir(), "igdb-overrides.json"))
+
+	defer func(seed, name, format string, supplement bool) {
+		seedFile, writeFileName, outputFormat, igdbSupplement = seed, name, format, supplement
+	}(seedFile, writeFileName, outputFormat, igdbSupplement)
+	seedFile = "../_test/data/game-data-list.xml"
+	writeFileName = ""
+	outputFormat = write.FormatNDJSON
+
+	// anything printed directly to the process' standard output would end up
+	// amid the translated data
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+
+	for _, supplement := range []bool{false, true} {
+		igdbSupplement = supplement
+
+		reader, writer, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("error creating pipe: %v", err)
+		}
+		os.Stdout = writer
+		leaked := make(chan []byte)
+		go func() {
+			data, _ := io.ReadAll(reader)
+			leaked <- data
+		}()
+
+		var output, progress bytes.Buffer
+		translateCmd.SetOut(&output)
+		translateCmd.SetErr(&progress)
+		// the mocked IGDB server lacks details for some games, which is reported once the output is written
+		err = translateCmd.RunE(translateCmd, nil)
+
+		os.Stdout = stdout
+		writer.Close()
+		if data := <-leaked; len(data) > 0 {
+			t.Errorf("expected nothing on the process' standard output with -i %v, got:\n%s", supplement, data)
+		}
+
+		var report *clz_translate.DiagnosticsReport
+		if err != nil && !errors.As(err, &report) {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if !strings.Contains(progress.String(), "attempt a games data translation") {
+			t.Errorf("expected progress on standard error with -i %v, got:\n%s", supplement, progress.String())
+		}
+
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		if len(lines) != 8 {
+			t.Fatalf("expected 8 NDJSON lines with -i %v, got %d:\n%s", supplement, len(lines), output.String())
+		}
+		for _, line := range lines {
+			var game domain.Game
+			if err := json.Unmarshal([]byte(line), &game); err != nil {
+				t.Errorf("expected only NDJSON on standard output, got %q", line)
+			}
+		}
+	}
+	translateCmd.SetOut(nil)
+	translateCmd.SetErr(nil)
+}
@@ -0,0 +1,102 @@
+package clz_translate
+
+import (
+	"fmt"
+	"main/src/domain"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CLZ writes dates and timestamps in the US locale without a timezone, so
+// every parsed value is treated as UTC.
+var (
+	clzDateLayouts = []struct {
+		layout    string
+		precision domain.DatePrecision
+	}{
+		{"1/2/2006", domain.DatePrecisionDay},
+		{"January 2006", domain.DatePrecisionMonth},
+		{"2006", domain.DatePrecisionYear},
+	}
+
+	clzTimestampLayouts = []string{
+		"1/2/2006 3:04:05 PM",
+		"1/2/2006 15:04:05",
+		"1/2/2006",
+	}
+)
+
+// clzDateDef is a (possibly partial) calendar date as exported by CLZ, e.g.
+//
+//	<releasedate>
+//	  <year><displayname>1990</displayname></year>
+//	  <month>1</month>
+//	  <date>January 1990</date>
+//	</releasedate>
+type clzDateDef struct {
+	Year  namingDef `xml:"year"`
+	Month int       `xml:"month"`
+	Day   int       `xml:"day"`
+	Date  string    `xml:"date"`
+}
+
+// clzTimestampDef is a date and time as exported by CLZ, e.g.
+// <dateadded><date>1/20/2019 1:43:16 PM</date></dateadded>.
+type clzTimestampDef struct {
+	Date string `xml:"date"`
+}
+
+func (d clzDateDef) toDomain() domain.Date {
+	year, err := strconv.Atoi(strings.TrimSpace(d.Year.DisplayName))
+	if err == nil && year > 0 {
+		if d.Month < 1 || d.Month > 12 {
+			return domain.NewDate(year, 0, 0)
+		}
+
+		return domain.NewDate(year, d.Month, d.Day)
+	}
+
+	// fall back to the formatted date when the structured parts are missing
+	return parseCLZDate(d.Date)
+}
+
+func (d clzTimestampDef) toTime() time.Time {
+	return parseCLZTimestamp(d.Date)
+}
+
+func parseCLZDate(value string) domain.Date {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return domain.Date{}
+	}
+
+	for _, format := range clzDateLayouts {
+		parsed, err := time.Parse(format.layout, value)
+		if err == nil {
+			return domain.Date{Time: parsed, Precision: format.precision}
+		}
+	}
+
+	// reported on standard error, which stays clear of translated data written to standard output
+	fmt.Fprintf(os.Stderr, "unrecognised CLZ date: %q\n", value)
+	return domain.Date{}
+}
+
+func parseCLZTimestamp(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range clzTimestampLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unrecognised CLZ timestamp: %q\n", value)
+	return time.Time{}
+}
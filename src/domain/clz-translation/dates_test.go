@@ -0,0 +1,68 @@
+package clz_translate
+
+import (
+	"encoding/xml"
+	"main/src/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCLZDateToDomain(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected domain.Date
+	}{
+		{
+			"<releasedate><year><displayname>1998</displayname></year><month>1</month><day>1</day><date>1/1/1998</date></releasedate>",
+			domain.NewDate(1998, 1, 1),
+		},
+		{
+			"<releasedate><year><displayname>1990</displayname></year><month>1</month><date>January 1990</date></releasedate>",
+			domain.NewDate(1990, 1, 0),
+		},
+		{
+			"<releasedate><year><displayname>1980</displayname></year><date>1980</date></releasedate>",
+			domain.NewDate(1980, 0, 0),
+		},
+		{
+			"<releasedate><date>7/24/1997</date></releasedate>",
+			domain.NewDate(1997, 7, 24),
+		},
+		{
+			"<releasedate/>",
+			domain.Date{},
+		},
+	}
+
+	for _, test := range tests {
+		var date clzDateDef
+		if err := xml.Unmarshal([]byte(test.input), &date); err != nil {
+			t.Fatalf("error unmarshalling %s: %v", test.input, err)
+		}
+
+		result := date.toDomain()
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Expected %#v for %s, but got %#v", test.expected, test.input, result)
+		}
+	}
+}
+
+func TestCLZTimestampToTime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"1/20/2019 1:43:16 PM", time.Date(2019, time.January, 20, 13, 43, 16, 0, time.UTC)},
+		{"12/3/2021", time.Date(2021, time.December, 3, 0, 0, 0, 0, time.UTC)},
+		{"", time.Time{}},
+		{"not a date", time.Time{}},
+	}
+
+	for _, test := range tests {
+		result := clzTimestampDef{Date: test.input}.toTime()
+		if !result.Equal(test.expected) {
+			t.Errorf("Expected %v for %q, but got %v", test.expected, test.input, result)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package clz_translate
+
+import (
+	"errors"
+	"fmt"
+	"main/src/domain"
+	"strings"
+)
+
+var errNoGameData = errors.New("no game data returned by IGDB")
+
+// MalformedXMLError is returned when the CLZ XML input cannot be decoded.
+//
+// Fields:
+//   - Err: The underlying XML decoding error.
+type MalformedXMLError struct {
+	Err error
+}
+
+func (e *MalformedXMLError) Error() string {
+	return fmt.Sprintf("malformed CLZ XML: %v", e.Err)
+}
+
+func (e *MalformedXMLError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownPlatformError is a warning for a game whose CLZ platform has no IGDB
+// mapping. The game is still searched for on IGDB, without its platform.
+//
+// Fields:
+//   - Platform: The CLZ platform name that could not be mapped.
+type UnknownPlatformError struct {
+	Platform domain.Platform
+}
+
+func (e *UnknownPlatformError) Error() string {
+	return fmt.Sprintf("unknown platform %q", e.Platform)
+}
+
+// EnrichmentError is recorded for a game that could not be supplemented with IGDB data.
+//
+// Fields:
+//   - IGDB_ID: The IGDB ID the game was matched to, zero if no match was found.
+//   - Err: The underlying error.
+type EnrichmentError struct {
+	IGDB_ID int
+	Err     error
+}
+
+func (e *EnrichmentError) Error() string {
+	if e.IGDB_ID == 0 {
+		return fmt.Sprintf("IGDB enrichment failed: %v", e.Err)
+	}
+
+	return fmt.Sprintf("IGDB enrichment failed for IGDB ID %d: %v", e.IGDB_ID, e.Err)
+}
+
+func (e *EnrichmentError) Unwrap() error {
+	return e.Err
+}
+
+// GameDiagnostic records a problem encountered while translating a single game.
+//
+// Fields:
+//   - Index: The position of the game in the translated collection.
+//   - Title: The title of the game.
+//   - Platform: The platform of the game.
+//   - Err: The problem encountered, an *EnrichmentError, or an *UnknownPlatformError for a warning.
+type GameDiagnostic struct {
+	Index    int
+	Title    string
+	Platform domain.Platform
+	Err      error
+}
+
+func (d GameDiagnostic) String() string {
+	return fmt.Sprintf("%s (%s): %v", d.Title, d.Platform, d.Err)
+}
+
+// DiagnosticsReport is returned alongside a translated collection when one or
+// more games could not be fully translated. The collection is still usable;
+// the report lists which games are affected and why.
+//
+// Fields:
+//   - Diagnostics: The per-game problems, in collection order.
+type DiagnosticsReport struct {
+	Diagnostics []GameDiagnostic
+}
+
+func newGameDiagnostic(index int, game domain.Game, err error) GameDiagnostic {
+	return GameDiagnostic{
+		Index:    index,
+		Title:    game.Title,
+		Platform: game.Platform,
+		Err:      err,
+	}
+}
+
+func (r *DiagnosticsReport) add(index int, game domain.Game, err error) {
+	r.Diagnostics = append(r.Diagnostics, newGameDiagnostic(index, game, err))
+}
+
+func (r *DiagnosticsReport) Error() string {
+	lines := []string{fmt.Sprintf("%d game(s) could not be fully translated:", len(r.Diagnostics))}
+	for _, diagnostic := range r.Diagnostics {
+		lines = append(lines, "  - "+diagnostic.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap exposes the per-game errors so they can be inspected with errors.Is and errors.As.
+func (r *DiagnosticsReport) Unwrap() []error {
+	errs := make([]error, 0, len(r.Diagnostics))
+	for _, diagnostic := range r.Diagnostics {
+		errs = append(errs, diagnostic.Err)
+	}
+
+	return errs
+}
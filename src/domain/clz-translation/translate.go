@@ -2,62 +2,74 @@ package clz_translate
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"iter"
 	"main/src/adapters/igdb"
 	"main/src/domain"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
-type clzXMLList struct {
-	GameList []clzXML `xml:"gamelist>game"`
-}
-
 type clzXML struct {
-	XMLName                     xml.Name    `xml:"game"`
-	PricechartingURL            string      `xml:"pricechartingurl"`
-	PricechartingLoose          float64     `xml:"pricechartingloose"`
-	PricechartingCIB            float64     `xml:"pricechartingcib"`
-	PricechartingNew            float64     `xml:"pricechartingnew"`
-	PricechartingValue          float64     `xml:"pricechartingvalue"`
-	Platform                    namingDef   `xml:"platform"`
-	CompletenessNum             string      `xml:"completenessnum"`
-	Completeness                string      `xml:"completeness"`
-	Condition                   string      `xml:"condition"`
-	LastModified                dateDef     `xml:"lastmodified>date"`
-	Quantity                    int         `xml:"quantity"`
-	Language                    string      `xml:"language"`
-	Publishers                  []namingDef `xml:"publishers>publisher"`
-	Developers                  []namingDef `xml:"developers>developer"`
-	Genres                      []namingDef `xml:"genres>genre"`
-	DateAdded                   dateDef     `xml:"dateadded>date"`
-	ReleaseDate                 dateDef     `xml:"releasedate>date"`
-	GameHardwareType            namingDef   `xml:"gameshardware"`
-	ThumbFilePath               string      `xml:"thumbfilepath"`
-	BPGameID                    int         `xml:"bpgameid"`
-	Region                      namingDef   `xml:"region"`
-	BPMediaID                   int         `xml:"bpmediaid"`
-	CLZPlatformID               int         `xml:"clzplatformid"`
-	BPGameLastReceivedRevision  int         `xml:"bpgamelastreceivedrevision"`
-	BPMediaLastReceivedRevision int         `xml:"bpmedialastreceivedrevision"`
-	Multiplayer                 string      `xml:"multiplayer"`
-	Format                      namingDef   `xml:"format"`
-	StorageDevice               string      `xml:"storagedevice"`
-	SubmissionDate              string      `xml:"submissiondate"`
-	Tags                        string      `xml:"tags"`
-	TitleFirstLetter            namingDef   `xml:"titlefirstletter"`
-	Title                       string      `xml:"title"`
-	Edition                     namingDef   `xml:"edition"`
-	Boxset                      string      `xml:"boxset"`
-	HasBox                      string      `xml:"hasbox"`
-	HasManual                   string      `xml:"hasmanual"`
-	Links                       []linkDef   `xml:"links>link"`
-}
-
-type dateDef struct {
-	Value time.Time
+	XMLName                     xml.Name        `xml:"game"`
+	ID                          int             `xml:"id"`
+	PricechartingURL            string          `xml:"pricechartingurl"`
+	PricechartingLoose          float64         `xml:"pricechartingloose"`
+	PricechartingCIB            float64         `xml:"pricechartingcib"`
+	PricechartingNew            float64         `xml:"pricechartingnew"`
+	PricechartingValue          float64         `xml:"pricechartingvalue"`
+	Platform                    namingDef       `xml:"platform"`
+	CompletenessNum             string          `xml:"completenessnum"`
+	Completeness                string          `xml:"completeness"`
+	Condition                   string          `xml:"condition"`
+	LastModified                clzTimestampDef `xml:"lastmodified"`
+	Quantity                    int             `xml:"quantity"`
+	Language                    namingDef       `xml:"language"`
+	Publishers                  []namingDef     `xml:"publishers>publisher"`
+	Developers                  []namingDef     `xml:"developers>developer"`
+	Genres                      []namingDef     `xml:"genres>genre"`
+	DateAdded                   clzTimestampDef `xml:"dateadded"`
+	ReleaseDate                 clzDateDef      `xml:"releasedate"`
+	GameHardwareType            namingDef       `xml:"gameshardware"`
+	ThumbFilePath               string          `xml:"thumbfilepath"`
+	BPGameID                    int             `xml:"bpgameid"`
+	Region                      namingDef       `xml:"region"`
+	BPMediaID                   int             `xml:"bpmediaid"`
+	CLZPlatformID               int             `xml:"clzplatformid"`
+	BPGameLastReceivedRevision  int             `xml:"bpgamelastreceivedrevision"`
+	BPMediaLastReceivedRevision int             `xml:"bpmedialastreceivedrevision"`
+	Multiplayer                 string          `xml:"multiplayer"`
+	Format                      namingDef       `xml:"format"`
+	StorageDevice               string          `xml:"storagedevice"`
+	SubmissionDate              string          `xml:"submissiondate"`
+	Tags                        []namingDef     `xml:"tags>tag"`
+	TitleFirstLetter            namingDef       `xml:"titlefirstletter"`
+	Title                       string          `xml:"title"`
+	Edition                     namingDef       `xml:"edition"`
+	Boxset                      string          `xml:"boxset"`
+	HasBox                      string          `xml:"hasbox"`
+	HasManual                   string          `xml:"hasmanual"`
+	Links                       []linkDef       `xml:"links>link"`
+	Series                      namingDef       `xml:"series"`
+	UPC                         string          `xml:"upc"`
+	Notes                       string          `xml:"notes"`
+	MyRating                    []ratingDef     `xml:"myrating"`
+	NrDisks                     int             `xml:"nrdisks"`
+	AudienceRating              namingDef       `xml:"audiencerating"`
+	CoverFront                  string          `xml:"coverfront"`
+	CoverBack                   string          `xml:"coverback"`
+	BackdropURL                 string          `xml:"backdropurl"`
+	PurchaseDate                clzDateDef      `xml:"purchasedate"`
+	PurchasePrice               string          `xml:"purchaseprice"`
+	Store                       namingDef       `xml:"store"`
+	Owner                       namingDef       `xml:"owner"`
+	Location                    namingDef       `xml:"location"`
+	Loans                       []loanDef       `xml:"loans>loan"`
 }
 
 type namingDef struct {
@@ -65,6 +77,21 @@ type namingDef struct {
 	SortName    string `xml:"sortname"`
 }
 
+// ratingDef is a CLZ rating, which is exported both as a plain value and as a
+// naming, e.g. <myrating>7</myrating><myrating><displayname>7</displayname></myrating>.
+type ratingDef struct {
+	Value       string `xml:",chardata"`
+	DisplayName string `xml:"displayname"`
+}
+
+type loanDef struct {
+	Loaner     namingDef       `xml:"loaner"`
+	LoanDate   clzTimestampDef `xml:"loandate"`
+	DueDate    clzTimestampDef `xml:"duedate"`
+	ReturnDate clzTimestampDef `xml:"returndate"`
+	Notes      string          `xml:"notes"`
+}
+
 type linkDef struct {
 	Description string `xml:"description"`
 	URL         string `xml:"url"`
@@ -94,173 +121,446 @@ func extractLinks(links []linkDef) []domain.Link {
 	return domainLinks
 }
 
-func retrieveIGDBSupplement(game domain.Game, igdbAdapter *igdb.IGDBAdapter) igdb.IGDBGameData {
-	igdbGameData := igdbAdapter.GetGameData([]int{game.IGDB_ID})
-	if igdbGameData[0].ID == 0 {
-		fmt.Println("No game data found in IGDB for CLZ game:", game.Title)
-		return igdb.IGDBGameData{}
+func extractLoans(loans []loanDef) []domain.Loan {
+	var domainLoans []domain.Loan
+
+	for _, loan := range loans {
+		domainLoans = append(domainLoans, domain.Loan{
+			DueDate:    loan.DueDate.toTime(),
+			LoanDate:   loan.LoanDate.toTime(),
+			Loaner:     loan.Loaner.DisplayName,
+			Notes:      strings.TrimSpace(loan.Notes),
+			ReturnDate: loan.ReturnDate.toTime(),
+		})
 	}
 
-	rateLimitStr := os.Getenv("IGDB_API_RATE_LIMIT")
-	rateLimit, err := strconv.Atoi(rateLimitStr)
-	if err != nil {
-		fmt.Printf("Invalid IGDB_API_RATE_LIMIT value: %v\n", err)
-		rateLimit = 0 // Default to 0 second if parsing fails
+	return domainLoans
+}
+
+func extractRating(ratings []ratingDef) float64 {
+	for _, rating := range ratings {
+		for _, value := range []string{rating.Value, rating.DisplayName} {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err == nil {
+				return parsed
+			}
+		}
 	}
-	sleepTime := time.Duration(rateLimit) * time.Second
-	time.Sleep(sleepTime)
 
-	return igdbGameData[0]
+	return 0
 }
 
-func translateGamesDataToDomain(clzXMLData string) []domain.Game {
-	var (
-		clzData clzXMLList
-	)
+// parsePrice parses a CLZ price, ignoring any currency symbol and thousands
+// separators, e.g. "$1,299.00".
+func parsePrice(value string) float64 {
+	cleaned := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == '-' {
+			return r
+		}
+		return -1
+	}, value)
 
-	err := xml.Unmarshal([]byte(clzXMLData), &clzData)
+	parsed, err := strconv.ParseFloat(cleaned, 64)
 	if err != nil {
-		log.Fatalf("error unmarshalling xml: %v", err)
+		return 0
+	}
+
+	return parsed
+}
+
+// applyIGDBData supplements a game with the data retrieved from IGDB. IGDB
+// genres, developers and publishers are merged into the CLZ values, recording
+// the provenance of every merged value.
+func applyIGDBData(game *domain.Game, data igdb.IGDBGameData) {
+	// IGDB leaves the release date out when it is unknown, which is kept as the zero time rather than the epoch
+	game.FirstReleaseDate = time.Time{}
+	if data.First_release_date != 0 {
+		game.FirstReleaseDate = time.Unix(int64(data.First_release_date), 0)
+	}
+	game.Storyline = data.Storyline
+	game.Summary = data.Summary
+	game.Cover = domain.Cover{
+		ID:    data.Cover.ID,
+		Width: data.Cover.Width,
+		URL:   data.Cover.URL,
+	}
+
+	game.Artworks = nil
+	for _, artwork := range data.Artworks {
+		game.Artworks = append(game.Artworks, domain.Artwork{ID: artwork.ID, Width: artwork.Width, URL: artwork.URL})
 	}
 
-	gameCollection := domain.GameCollection{
-		Games: []domain.Game{},
+	game.Screenshots = nil
+	for _, screenshot := range data.Screenshots {
+		game.Screenshots = append(game.Screenshots, domain.Artwork{ID: screenshot.ID, Width: screenshot.Width, URL: screenshot.URL})
 	}
 
-	for _, game := range clzData.GameList {
-		newGame := domain.Game{
-			Boxset: game.Boxset == "true",
-			Completeness: domain.Completeness{
-				HasBox:    game.HasBox == "true",
-				HasManual: game.HasManual == "true",
-				HasGame:   game.Quantity > 0,
-			},
-			Condition:          game.Condition,
-			DateAcquired:       game.DateAdded.Value,
-			Developers:         extractDisplayNames(game.Developers),
-			Edition:            game.Edition.DisplayName,
-			Format:             game.Format.DisplayName,
-			Genres:             extractDisplayNames(game.Genres),
-			HardwareType:       game.GameHardwareType.DisplayName,
-			Links:              extractLinks(game.Links),
-			Multiplayer:        game.Multiplayer == "true",
-			Platform:           domain.Platform(game.Platform.DisplayName),
-			PricechartingValue: game.PricechartingValue,
-			Publishers:         extractDisplayNames(game.Publishers),
-			Quantity:           game.Quantity,
-			Region:             game.Region.DisplayName,
-			ReleaseDate:        game.ReleaseDate.Value,
-			Series:             "",
-			Title:              game.Title,
+	game.Videos = nil
+	for _, video := range data.Videos {
+		game.Videos = append(game.Videos, domain.Video{Name: video.Name, VideoID: video.Video_id})
+	}
+
+	game.Franchises = nil
+	for _, franchise := range data.Franchises {
+		game.Franchises = append(game.Franchises, franchise.Name)
+	}
+
+	genres := []string{}
+	for _, genre := range data.Genres {
+		genres = append(genres, genre.Name)
+	}
+
+	developers, publishers := []string{}, []string{}
+	for _, involved := range data.Involved_companies {
+		if involved.Developer {
+			developers = append(developers, involved.Company.Name)
+		}
+		if involved.Publisher {
+			publishers = append(publishers, involved.Company.Name)
 		}
+	}
+
+	game.Genres, game.Provenance.Genres = domain.MergeValues(game.Genres, genres)
+	game.Developers, game.Provenance.Developers = domain.MergeValues(game.Developers, developers)
+	game.Publishers, game.Provenance.Publishers = domain.MergeValues(game.Publishers, publishers)
+}
 
-		gameCollection.Games = append(gameCollection.Games, newGame)
+func translateGameToDomain(game clzXML) domain.Game {
+	return domain.Game{
+		AudienceRating: game.AudienceRating.DisplayName,
+		BPGameID:       game.BPGameID,
+		Boxset:         game.Boxset == "true",
+		CLZImages: domain.CLZImages{
+			Backdrop:   game.BackdropURL,
+			BackCover:  game.CoverBack,
+			FrontCover: game.CoverFront,
+			Thumbnail:  game.ThumbFilePath,
+		},
+		CLZ_ID: game.ID,
+		Completeness: domain.Completeness{
+			HasBox:    game.HasBox == "true",
+			HasManual: game.HasManual == "true",
+			HasGame:   game.Quantity > 0,
+		},
+		Condition:          game.Condition,
+		DateAcquired:       game.DateAdded.toTime(),
+		Developers:         extractDisplayNames(game.Developers),
+		DiskCount:          game.NrDisks,
+		Edition:            game.Edition.DisplayName,
+		Format:             game.Format.DisplayName,
+		Genres:             extractDisplayNames(game.Genres),
+		HardwareType:       game.GameHardwareType.DisplayName,
+		Language:           game.Language.DisplayName,
+		LastModified:       game.LastModified.toTime(),
+		Links:              extractLinks(game.Links),
+		Loans:              extractLoans(game.Loans),
+		Location:           game.Location.DisplayName,
+		Multiplayer:        game.Multiplayer == "true",
+		MyRating:           extractRating(game.MyRating),
+		Notes:              strings.TrimSpace(game.Notes),
+		Owner:              game.Owner.DisplayName,
+		Platform:           domain.Platform(game.Platform.DisplayName),
+		PricechartingCIB:   game.PricechartingCIB,
+		PricechartingLoose: game.PricechartingLoose,
+		PricechartingNew:   game.PricechartingNew,
+		PricechartingURL:   game.PricechartingURL,
+		PricechartingValue: game.PricechartingValue,
+		Publishers:         extractDisplayNames(game.Publishers),
+		PurchaseDate:       game.PurchaseDate.toDomain(),
+		PurchasePrice:      parsePrice(game.PurchasePrice),
+		Quantity:           game.Quantity,
+		Region:             game.Region.DisplayName,
+		ReleaseDate:        game.ReleaseDate.toDomain(),
+		Series:             game.Series.DisplayName,
+		Store:              game.Store.DisplayName,
+		Tags:               extractDisplayNames(game.Tags),
+		Title:              game.Title,
+		UPC:                strings.TrimSpace(game.UPC),
 	}
+}
 
-	return gameCollection.Games
+// StreamCLZ decodes CLZ XML game entries one at a time from the provided reader
+// and yields each one translated into a domain.Game. Only the game currently
+// being decoded is held in memory, so large exports can be processed and
+// written out incrementally.
+//
+// Parameters:
+//   - reader: An io.Reader providing the CLZ XML data.
+//
+// Returns:
+//   - iter.Seq2[domain.Game, error]: An iterator over the translated games. If the
+//     XML is malformed the iterator yields a *MalformedXMLError once and stops.
+func StreamCLZ(reader io.Reader) iter.Seq2[domain.Game, error] {
+	return func(yield func(domain.Game, error) bool) {
+		decoder := xml.NewDecoder(reader)
+
+		for {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(domain.Game{}, &MalformedXMLError{Err: err})
+				return
+			}
+
+			start, ok := token.(xml.StartElement)
+			if !ok || start.Name.Local != "game" {
+				continue
+			}
+
+			var game clzXML
+			if err := decoder.DecodeElement(&game, &start); err != nil {
+				yield(domain.Game{}, &MalformedXMLError{Err: err})
+				return
+			}
+
+			if !yield(translateGameToDomain(game), nil) {
+				return
+			}
+		}
+	}
 }
 
+// generateBatchQueries groups the IGDB IDs of the matched games into batches
+// that fit within IGDB's limit of results per query. Unmatched games are left
+// out and IDs shared by several games are only requested once.
 func generateBatchQueries(gameCollection []domain.Game) [][]int {
 	batchedQueries := [][]int{}
-	batchSize := 3
+	batch := []int{}
+	seen := map[int]bool{}
 
-	for i := 0; i < len(gameCollection); i += batchSize {
-		end := i + batchSize
-
-		if end > len(gameCollection) {
-			end = len(gameCollection)
+	for _, game := range gameCollection {
+		if game.IGDB_ID == 0 || seen[game.IGDB_ID] {
+			continue
 		}
+		seen[game.IGDB_ID] = true
 
-		batch := []int{}
-		for _, game := range gameCollection[i:end] {
-			batch = append(batch, game.IGDB_ID)
+		batch = append(batch, game.IGDB_ID)
+		if len(batch) == igdb.MaxQueryResults {
+			batchedQueries = append(batchedQueries, batch)
+			batch = []int{}
 		}
+	}
 
+	if len(batch) > 0 {
 		batchedQueries = append(batchedQueries, batch)
 	}
 
 	return batchedQueries
 }
 
-// TranslateCLZ translates a CLZ XML input string into a domain.GameCollection.
-// It unmarshals the XML input into a clzXMLList structure and then iterates
-// through the list of games to populate a domain.GameCollection with the
-// relevant game data.
+// translateChunkSize is the number of games TranslateCLZ decodes and
+// supplements with IGDB data at a time, handing each chunk to
+// TranslateOptions.OnGame once it is complete. A chunk's details fit in a
+// single IGDB query.
+var translateChunkSize = igdb.MaxQueryResults
+
+// TranslateOptions configures how TranslateCLZ translates a CLZ collection.
+//
+// Fields:
+//   - IGDBSupplement: Whether to supplement the data with IGDB data.
+//   - IGDBCacheDir: The directory IGDB responses are cached in, caching is disabled when empty.
+//   - IGDBCacheTTL: The time cached IGDB responses are reused for.
+//   - RefreshIGDBCache: Whether to ignore cached IGDB responses and re-query IGDB.
+//   - IGDBMatchThreshold: The minimum confidence for an IGDB match to be accepted, below which the game is left
+//     unmatched. igdb.DefaultMatchThreshold when nil, zero accepts every best match.
+//   - IGDBOverrides: Manual IGDB match decisions, consulted before searching for a game.
+//   - OnGame: Called with every translated game in collection order, as soon as its chunk of games has been
+//     supplemented with IGDB data, if set. Returning false stops the translation.
+//   - OnWarning: Called with every warning-level diagnostic, such as an *UnknownPlatformError, if set. Warnings
+//     are not part of the returned *DiagnosticsReport, as the games are still translated.
+//   - Output: The writer IGDB progress and failed lookups are printed to, os.Stdout when nil.
+type TranslateOptions struct {
+	IGDBSupplement     bool
+	IGDBCacheDir       string
+	IGDBCacheTTL       time.Duration
+	RefreshIGDBCache   bool
+	IGDBMatchThreshold *float64
+	IGDBOverrides      domain.MatchOverrides
+	OnGame             func(domain.Game) bool
+	OnWarning          func(GameDiagnostic)
+	Output             io.Writer
+}
+
+// TranslateCLZ translates CLZ XML input into a domain.GameCollection. It
+// decodes the game entries of the XML input with StreamCLZ a chunk at a time,
+// supplementing each chunk with IGDB data before decoding the next, so only a
+// chunk of games is held in memory when they are handed to
+// TranslateOptions.OnGame.
 //
 // Parameters:
-//   - input: A string containing the CLZ XML data.
+//   - input: An io.Reader providing the CLZ XML data.
+//   - options: A TranslateOptions struct configuring the translation and IGDB supplement.
 //
 // Returns:
-//   - domain.GameCollection: A collection of games translated from the CLZ XML data.
-//   - igdbSupplement: A boolean indicating whether to supplement the data with IGDB data.
-//
-// The function will log a fatal error if the XML unmarshalling fails.
-func TranslateCLZ(input string, igdbSupplement bool) domain.GameCollection {
+//   - domain.GameCollection: A collection of games translated from the CLZ XML data. When
+//     OnGame is set the games are handed to it instead, and the collection holds none.
+//   - error: A *MalformedXMLError if the XML cannot be decoded, in which case the
+//     collection is empty, though the games decoded before may have been handed to
+//     OnGame. A *DiagnosticsReport if some games could not be fully translated, in
+//     which case the collection is still complete but those games lack IGDB data.
+func TranslateCLZ(input io.Reader, options TranslateOptions) (domain.GameCollection, error) {
 	var (
 		igdbAdapter *igdb.IGDBAdapter = nil
+		report      DiagnosticsReport
+		err         error
 	)
 
-	gameCollection := translateGamesDataToDomain(input)
+	if options.IGDBSupplement {
+		adapterInit := igdb.IGDBAdapterInitFromEnv()
+		adapterInit.CacheDir = options.IGDBCacheDir
+		adapterInit.CacheTTL = options.IGDBCacheTTL
+		adapterInit.RefreshCache = options.RefreshIGDBCache
+		adapterInit.MatchThreshold = options.IGDBMatchThreshold
+		adapterInit.Overrides = options.IGDBOverrides
+		adapterInit.Output = options.Output
+		igdbAdapter, err = igdb.NewIGDBAdapter(adapterInit)
+		if err != nil {
+			return domain.GameCollection{}, err
+		}
+	}
 
-	if igdbSupplement {
-		igdbAdapter = igdb.NewIGDBAdapter(igdb.IGDBAdapterInit{
-			AuthBaseUrl:      os.Getenv("IGDB_AUTH_BASE_URL"),
-			AuthUrlPath:      os.Getenv("IGDB_AUTH_PATH"),
-			AuthClientId:     os.Getenv("IGDB_CLIENT_ID"),
-			AuthClientSecret: os.Getenv("IGDB_CLIENT_SECRET"),
-			IGDBBaseUrl:      os.Getenv("IGDB_BASE_URL"),
-		})
+	collection := domain.GameCollection{
+		SchemaVersion: domain.SchemaVersion,
+		Games:         []domain.Game{},
+	}
+
+	next, stop := iter.Pull2(StreamCLZ(input))
+	defer stop()
 
-		// perform fuzzy find for all games in order to get IGDB_ID
-		gameCollectionWithIgdbIds := igdbAdapter.FuzzyFindGamesList(gameCollection)
+	for offset, stopped := 0, false; !stopped; {
+		chunk, err := pullChunk(next, translateChunkSize)
+		if err != nil {
+			return domain.GameCollection{}, err
+		}
+		if len(chunk) == 0 {
+			break
+		}
 
-		batchQueries := generateBatchQueries(gameCollectionWithIgdbIds)
+		if igdbAdapter != nil {
+			supplementIGDBData(igdbAdapter, chunk, offset, options, &report)
+		}
+		offset += len(chunk)
 
-		rateLimitStr := os.Getenv("IGDB_API_RATE_LIMIT")
-		rateLimit, err := strconv.Atoi(rateLimitStr)
+		if options.OnGame == nil {
+			collection.Games = append(collection.Games, chunk...)
+			continue
+		}
+		for _, game := range chunk {
+			if !options.OnGame(game) {
+				stopped = true
+				break
+			}
+		}
+	}
+
+	if len(report.Diagnostics) > 0 {
+		sort.SliceStable(report.Diagnostics, func(i, j int) bool {
+			return report.Diagnostics[i].Index < report.Diagnostics[j].Index
+		})
+		return collection, &report
+	}
+
+	return collection, nil
+}
+
+// pullChunk returns the next games decoded by StreamCLZ, up to size games,
+// and fewer only at the end of the input.
+func pullChunk(next func() (domain.Game, error, bool), size int) ([]domain.Game, error) {
+	chunk := make([]domain.Game, 0, size)
+	for len(chunk) < size {
+		game, err, ok := next()
+		if !ok {
+			break
+		}
 		if err != nil {
-			fmt.Printf("Invalid IGDB_API_RATE_LIMIT value: %v\n", err)
-			rateLimit = 500 // default to 500 milliseconds if parsing fails
+			return nil, err
 		}
-		sleepTime := time.Duration(rateLimit) * time.Millisecond
+		chunk = append(chunk, game)
+	}
+
+	return chunk, nil
+}
+
+// supplementIGDBData supplements the games with IGDB data in place, adding the
+// games that could not be supplemented to the report.
+//
+// Parameters:
+//   - igdbAdapter: The IGDB adapter to match and retrieve the games with.
+//   - games: The games to supplement.
+//   - offset: The position of the first game in the collection, to report the games by.
+//   - options: The translation options, holding the manual IGDB match decisions the adapter consults.
+//   - report: The report to add the games that could not be supplemented to.
+func supplementIGDBData(igdbAdapter *igdb.IGDBAdapter, games []domain.Game, offset int, options TranslateOptions, report *DiagnosticsReport) {
+	output := options.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	// games with an unknown platform are still searched for, by title and
+	// release date alone, so they are only warned about
+	for idx, game := range games {
+		// overridden games are not searched for, so need no platform mapping
+		_, overridden := options.IGDBOverrides.Find(game)
+		if _, ok := domain.PlatformMap.IGDBPlatformID(string(game.Platform)); !ok && !overridden && options.OnWarning != nil {
+			options.OnWarning(newGameDiagnostic(offset+idx, game, &UnknownPlatformError{Platform: game.Platform}))
+		}
+	}
+
+	// perform fuzzy find for all games in order to get IGDB_ID
+	searchedGames, lookupErrors := igdbAdapter.FuzzyFindGamesList(games)
+	copy(games, searchedGames)
 
-		for i, batchQuery := range batchQueries {
-			fmt.Printf("Processing batch %d/%d with %d games...\n", i+1, len(batchQueries), len(batchQuery))
+	batchQueries := generateBatchQueries(games)
 
-			// retrieve IGDB data for each batch
-			igdbData := igdbAdapter.GetGameData(batchQuery)
+	// retrieve IGDB data for all batches concurrently, within the adapter's rate limit
+	batchResults, batchErrors := igdbAdapter.GetGameDataBatches(batchQueries)
 
-			for _, data := range igdbData {
+	enriched := make([]bool, len(games))
+	failedQueries := map[int]error{}
 
-				var matchIdx = -1
-				for idx, game := range gameCollectionWithIgdbIds {
-					if game.IGDB_ID == data.ID {
-						matchIdx = idx
-						break
+	for i, batchQuery := range batchQueries {
+		igdbData, err := batchResults[i], batchErrors[i]
+		if err != nil {
+			fmt.Fprintf(output, "error getting IGDB data for batch %d: %v\n", i+1, err)
+			for _, id := range batchQuery {
+				failedQueries[id] = err
+			}
+		}
+
+		for _, data := range igdbData {
+
+			var matched = false
+			for idx, game := range games {
+				if game.IGDB_ID == data.ID {
+					matched = true
+
+					// a game is only merged with its IGDB data once, even if the data is returned by several batches
+					if enriched[idx] {
+						continue
 					}
-				}
-				if matchIdx == -1 {
-					fmt.Printf("No matching game found for IGDB_ID %d\n", data.ID)
-					continue
-				}
 
-				gameCollectionWithIgdbIds[matchIdx].FirstReleaseDate = time.Unix(int64(data.First_release_date), 0)
-				gameCollectionWithIgdbIds[matchIdx].Storyline = data.Storyline
-				gameCollectionWithIgdbIds[matchIdx].Summary = data.Summary
-				gameCollectionWithIgdbIds[matchIdx].Cover = domain.Cover{
-					ID:    data.Cover.ID,
-					Width: data.Cover.Width,
-					URL:   data.Cover.URL,
+					applyIGDBData(&games[idx], data)
+					enriched[idx] = true
 				}
 			}
-
-			time.Sleep(sleepTime)
+			if !matched {
+				fmt.Fprintf(output, "No matching game found for IGDB_ID %d\n", data.ID)
+			}
 		}
-
-		gameCollection = gameCollectionWithIgdbIds
 	}
 
-	return domain.GameCollection{
-		Games: gameCollection,
+	for idx, game := range games {
+		switch {
+		case enriched[idx], errors.Is(lookupErrors[idx], igdb.ErrEnrichmentSkipped):
+			continue
+		case lookupErrors[idx] != nil:
+			report.add(offset+idx, game, &EnrichmentError{Err: lookupErrors[idx]})
+		case failedQueries[game.IGDB_ID] != nil:
+			report.add(offset+idx, game, &EnrichmentError{IGDB_ID: game.IGDB_ID, Err: failedQueries[game.IGDB_ID]})
+		default:
+			report.add(offset+idx, game, &EnrichmentError{IGDB_ID: game.IGDB_ID, Err: errNoGameData})
+		}
 	}
 }
@@ -1,10 +1,19 @@
 package clz_translate
 
 import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
 	"main/src/_test/mocks"
+	"main/src/adapters/igdb"
 	"main/src/domain"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -42,19 +51,27 @@ func TestTranslateCLZ(t *testing.T) {
 
 	input := string(data)
 	expectedOutput := domain.Game{
-		Boxset: false,
+		BPGameID: 28697,
+		Boxset:   false,
+		CLZImages: domain.CLZImages{
+			FrontCover: `C:\Users\joe_c\Documents\Game Collector\Images\1XtremeGreatestHitsPlayS812_f.jpg`,
+			Thumbnail:  `C:\Users\joe_c\Documents\Game Collector\Thumbnails\24E895823CE3F98A2D29B6E3D634AB54.jpg`,
+		},
+		CLZ_ID: 812,
 		Completeness: domain.Completeness{
 			HasBox:    false,
 			HasManual: false,
 			HasGame:   true,
 		},
 		Condition:    "",
-		DateAcquired: time.Time{},
+		DateAcquired: time.Date(2019, time.January, 20, 13, 43, 16, 0, time.UTC),
 		Developers:   []string{"Sony Interactive Studios America"},
+		DiskCount:    1,
 		Edition:      "Greatest Hits",
 		Format:       "CD-ROM",
 		Genres:       []string{"Racing", "Sports"},
 		HardwareType: "Game",
+		LastModified: time.Date(2022, time.January, 19, 19, 38, 46, 0, time.UTC),
 		Links: []domain.Link{
 			{
 				Description: "1Xtreme at Core for Games",
@@ -67,16 +84,23 @@ func TestTranslateCLZ(t *testing.T) {
 		},
 		Multiplayer:        false,
 		Platform:           domain.PlayStation,
+		PricechartingCIB:   6.67,
+		PricechartingLoose: 5.97,
+		PricechartingNew:   23.88,
+		PricechartingURL:   "https://www.pricecharting.com/game/Playstation/1Xtreme",
 		PricechartingValue: 5.97,
 		Publishers:         []string{"Sony Computer Entertainment America", "And Another One"},
 		Quantity:           1,
 		Region:             "",
-		ReleaseDate:        time.Time{},
+		ReleaseDate:        domain.NewDate(1998, 1, 1),
 		Series:             "",
 		Title:              "1Xtreme (Greatest Hits)",
 	}
 
-	actualOutput := TranslateCLZ(input, false)
+	actualOutput, err := TranslateCLZ(strings.NewReader(input), TranslateOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
 
 	if len(actualOutput.Games) != 8 {
 		t.Errorf("expected 8 games, got %d", len(actualOutput.Games))
@@ -86,7 +110,25 @@ func TestTranslateCLZ(t *testing.T) {
 		t.Errorf("\nexpected \n%#v,\ngot \n%#v", expectedOutput, actualOutput.Games[0])
 	}
 
-	actualOutputWithIGDBSupplement := TranslateCLZ(input, true)
+	// the mocked IGDB server only has details for some of the matched games, the
+	// rest should be reported without preventing the collection from translating
+	actualOutputWithIGDBSupplement, err := TranslateCLZ(strings.NewReader(input), TranslateOptions{IGDBSupplement: true})
+
+	var report *DiagnosticsReport
+	if !errors.As(err, &report) {
+		t.Fatalf("expected a DiagnosticsReport, got %v", err)
+	}
+
+	var enrichmentErr *EnrichmentError
+	if !errors.As(err, &enrichmentErr) {
+		t.Errorf("expected the report to contain an EnrichmentError, got %v", err)
+	}
+
+	for _, diagnostic := range report.Diagnostics {
+		if diagnostic.Index == 0 {
+			t.Errorf("expected no diagnostic for the first game, got %s", diagnostic)
+		}
+	}
 
 	if len(actualOutputWithIGDBSupplement.Games) != 8 {
 		t.Errorf("expected 8 games, got %d", len(actualOutputWithIGDBSupplement.Games))
@@ -103,4 +145,391 @@ func TestTranslateCLZ(t *testing.T) {
 	if actualOutputWithIGDBSupplement.Games[0].Summary != "A summary supplement from mocked IGDB data for test for 1Xtreme (Greatest Hits)." {
 		t.Errorf("expected summary to be 'A summary supplement from mocked IGDB data for test for 1Xtreme (Greatest Hits).', got '%s'", actualOutputWithIGDBSupplement.Games[0].Summary)
 	}
+
+	enrichedGame := actualOutputWithIGDBSupplement.Games[0]
+
+	expectedGenres := []string{"Racing", "Sports", "Sport"}
+	if !reflect.DeepEqual(enrichedGame.Genres, expectedGenres) {
+		t.Errorf("expected genres %v, got %v", expectedGenres, enrichedGame.Genres)
+	}
+
+	expectedDevelopers := []string{"Sony Interactive Studios America", "989 Studios"}
+	if !reflect.DeepEqual(enrichedGame.Developers, expectedDevelopers) {
+		t.Errorf("expected developers %v, got %v", expectedDevelopers, enrichedGame.Developers)
+	}
+
+	expectedPublishers := []string{"Sony Computer Entertainment America", "And Another One"}
+	if !reflect.DeepEqual(enrichedGame.Publishers, expectedPublishers) {
+		t.Errorf("expected publishers %v, got %v", expectedPublishers, enrichedGame.Publishers)
+	}
+
+	expectedProvenance := domain.Provenance{
+		Developers: map[string][]domain.Source{
+			"Sony Interactive Studios America": {domain.SourceCLZ, domain.SourceIGDB},
+			"989 Studios":                      {domain.SourceIGDB},
+		},
+		Genres: map[string][]domain.Source{
+			"Racing": {domain.SourceCLZ, domain.SourceIGDB},
+			"Sports": {domain.SourceCLZ},
+			"Sport":  {domain.SourceIGDB},
+		},
+		Publishers: map[string][]domain.Source{
+			"Sony Computer Entertainment America": {domain.SourceCLZ, domain.SourceIGDB},
+			"And Another One":                     {domain.SourceCLZ},
+		},
+	}
+	if !reflect.DeepEqual(enrichedGame.Provenance, expectedProvenance) {
+		t.Errorf("expected provenance %+v, got %+v", expectedProvenance, enrichedGame.Provenance)
+	}
+
+	if len(enrichedGame.Artworks) != 1 || enrichedGame.Artworks[0].ID != 358989 {
+		t.Errorf("expected artwork 358989, got %+v", enrichedGame.Artworks)
+	}
+
+	if len(enrichedGame.Videos) != 2 || enrichedGame.Videos[0].VideoID != "mR6ZfUwPgqk" {
+		t.Errorf("expected 2 videos starting with mR6ZfUwPgqk, got %+v", enrichedGame.Videos)
+	}
+
+	if len(enrichedGame.Franchises) != 0 {
+		t.Errorf("expected no franchises, got %v", enrichedGame.Franchises)
+	}
+}
+
+func TestTranslateGameToDomainPersonalFields(t *testing.T) {
+	input := `<game>
+  <title>NiGHTS into Dreams...</title>
+  <series><displayname>NiGHTS</displayname><sortname>NiGHTS</sortname></series>
+  <language><displayname>Japanese</displayname></language>
+  <audiencerating><displayname>Kids to Adults</displayname></audiencerating>
+  <tags><tag><displayname>Import</displayname></tag><tag><displayname>Favourite</displayname></tag></tags>
+  <upc> 4974365090463 </upc>
+  <myrating>8</myrating>
+  <myrating><displayname>8</displayname><sortname>8</sortname></myrating>
+  <coverback>C:\Images\NiGHTS_b.jpg</coverback>
+  <backdropurl>C:\Images\NiGHTS_d.jpg</backdropurl>
+  <notes>&#8226; Japanese version with no spine
+  </notes>
+  <purchasedate><year><displayname>2020</displayname></year><month>6</month><date>June 2020</date></purchasedate>
+  <purchaseprice>$1,024.50</purchaseprice>
+  <store><displayname>Retro Store</displayname></store>
+  <owner><displayname>Joe</displayname></owner>
+  <location><displayname>Shelf A</displayname></location>
+  <loans>
+    <loan>
+      <loaner><displayname>Sam</displayname></loaner>
+      <loandate><date>3/1/2021</date></loandate>
+      <duedate><date>4/1/2021</date></duedate>
+    </loan>
+  </loans>
+</game>`
+
+	var game clzXML
+	if err := xml.Unmarshal([]byte(input), &game); err != nil {
+		t.Fatalf("error unmarshalling test data: %v", err)
+	}
+
+	actual := translateGameToDomain(game)
+
+	if actual.Series != "NiGHTS" || actual.Language != "Japanese" || actual.AudienceRating != "Kids to Adults" {
+		t.Errorf("expected series, language and audience rating to be translated, got %q, %q, %q", actual.Series, actual.Language, actual.AudienceRating)
+	}
+
+	if !reflect.DeepEqual(actual.Tags, []string{"Import", "Favourite"}) {
+		t.Errorf("expected tags to be translated, got %v", actual.Tags)
+	}
+
+	if actual.UPC != "4974365090463" || actual.MyRating != 8 || actual.Notes != "• Japanese version with no spine" {
+		t.Errorf("expected UPC, rating and notes to be translated, got %q, %v, %q", actual.UPC, actual.MyRating, actual.Notes)
+	}
+
+	if actual.CLZImages.BackCover != `C:\Images\NiGHTS_b.jpg` || actual.CLZImages.Backdrop != `C:\Images\NiGHTS_d.jpg` {
+		t.Errorf("expected CLZ images to be translated, got %+v", actual.CLZImages)
+	}
+
+	if actual.PurchaseDate != domain.NewDate(2020, 6, 0) || actual.PurchasePrice != 1024.5 {
+		t.Errorf("expected purchase date and price to be translated, got %v, %v", actual.PurchaseDate, actual.PurchasePrice)
+	}
+
+	if actual.Store != "Retro Store" || actual.Owner != "Joe" || actual.Location != "Shelf A" {
+		t.Errorf("expected store, owner and location to be translated, got %q, %q, %q", actual.Store, actual.Owner, actual.Location)
+	}
+
+	expectedLoans := []domain.Loan{{
+		Loaner:   "Sam",
+		LoanDate: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:  time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(actual.Loans, expectedLoans) {
+		t.Errorf("expected loans %+v, got %+v", expectedLoans, actual.Loans)
+	}
+}
+
+func TestStreamCLZ(t *testing.T) {
+	file, err := os.Open("../../_test/data/game-data-list.xml")
+	if err != nil {
+		t.Fatalf("error opening test data: %v", err)
+	}
+	defer file.Close()
+
+	var titles []string
+	for game, err := range StreamCLZ(file) {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		titles = append(titles, game.Title)
+	}
+
+	if len(titles) != 8 {
+		t.Errorf("expected 8 games, got %d", len(titles))
+	}
+
+	if titles[0] != "1Xtreme (Greatest Hits)" {
+		t.Errorf("expected first title to be '1Xtreme (Greatest Hits)', got '%s'", titles[0])
+	}
+
+	// stopping early should not decode the remaining games
+	count := 0
+	for range StreamCLZ(strings.NewReader("<gamelist><game><title>A</title></game><game><title>B</title></game></gamelist>")) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected iteration to stop after 1 game, got %d", count)
+	}
+
+	// malformed XML should be reported rather than silently ignored
+	var streamErr error
+	for _, err := range StreamCLZ(strings.NewReader("<gamelist><game><title>A</title></gamelist>")) {
+		streamErr = err
+	}
+	var malformedErr *MalformedXMLError
+	if !errors.As(streamErr, &malformedErr) {
+		t.Errorf("expected a MalformedXMLError, got %v", streamErr)
+	}
+}
+
+func TestTranslateCLZMalformedXML(t *testing.T) {
+	collection, err := TranslateCLZ(strings.NewReader("<gamelist><game><title>A</title></gamelist>"), TranslateOptions{})
+
+	var malformedErr *MalformedXMLError
+	if !errors.As(err, &malformedErr) {
+		t.Errorf("expected a MalformedXMLError, got %v", err)
+	}
+
+	if len(collection.Games) != 0 {
+		t.Errorf("expected no games, got %d", len(collection.Games))
+	}
+}
+
+func TestTranslateCLZUnknownPlatform(t *testing.T) {
+	input := "<gamelist><game><title>Super Mario Bros. 3</title><platform><displayname>Virtual Boy 2</displayname></platform></game></gamelist>"
+
+	warnings := []GameDiagnostic{}
+	collection, err := TranslateCLZ(strings.NewReader(input), TranslateOptions{
+		IGDBSupplement: true,
+		OnWarning: func(diagnostic GameDiagnostic) {
+			warnings = append(warnings, diagnostic)
+		},
+	})
+
+	// the unknown platform is only warned about, not reported as a failure
+	var platformErr *UnknownPlatformError
+	if errors.As(err, &platformErr) {
+		t.Errorf("expected the unknown platform not to be reported, got %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+
+	if !errors.As(warnings[0].Err, &platformErr) {
+		t.Fatalf("expected an UnknownPlatformError, got %v", warnings[0].Err)
+	}
+
+	if platformErr.Platform != "Virtual Boy 2" {
+		t.Errorf("expected platform 'Virtual Boy 2', got '%s'", platformErr.Platform)
+	}
+
+	if len(collection.Games) != 1 {
+		t.Fatalf("expected 1 game, got %d", len(collection.Games))
+	}
+
+	// the game is still searched for, without its platform
+	if collection.Games[0].IGDB_ID != 3 {
+		t.Errorf("expected the game to be matched to IGDB ID 3, got %d", collection.Games[0].IGDB_ID)
+	}
+}
+
+func TestTranslateCLZOnGame(t *testing.T) {
+	data, err := os.ReadFile("../../_test/data/game-data-list.xml")
+	if err != nil {
+		t.Fatalf("error reading test data: %v", err)
+	}
+
+	var mu sync.Mutex
+	requestCount := 0
+	mockIGDBServer := mocks.GetTestIGDBServer()
+	defer mockIGDBServer.Close()
+	countingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestCount++
+		mu.Unlock()
+
+		proxyRequest, _ := http.NewRequest(r.Method, mockIGDBServer.URL+r.URL.Path, r.Body)
+		response, err := http.DefaultClient.Do(proxyRequest)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer response.Body.Close()
+		io.Copy(w, response.Body)
+	}))
+	defer countingServer.Close()
+	t.Setenv("IGDB_BASE_URL", countingServer.URL)
+
+	defer func(size int) { translateChunkSize = size }(translateChunkSize)
+	translateChunkSize = 3
+
+	expected, _ := TranslateCLZ(bytes.NewReader(data), TranslateOptions{})
+	requestCount = 0
+
+	// every game is handed over once its chunk is supplemented, before the next chunk is requested
+	var handed []domain.Game
+	var requestCounts []int
+	collection, _ := TranslateCLZ(bytes.NewReader(data), TranslateOptions{
+		IGDBSupplement: true,
+		OnGame: func(game domain.Game) bool {
+			mu.Lock()
+			defer mu.Unlock()
+			handed = append(handed, game)
+			requestCounts = append(requestCounts, requestCount)
+			return true
+		},
+	})
+
+	if len(handed) != len(expected.Games) || len(collection.Games) != 0 {
+		t.Fatalf("expected the 8 games to be handed over instead of collected, got %d handed and %d collected", len(handed), len(collection.Games))
+	}
+	for i, game := range handed {
+		if game.CLZ_ID != expected.Games[i].CLZ_ID {
+			t.Errorf("expected game %d to be CLZ ID %d, got %d", i, expected.Games[i].CLZ_ID, game.CLZ_ID)
+		}
+	}
+	if requestCounts[0] != 2 || requestCounts[0] >= requestCounts[len(requestCounts)-1] {
+		t.Errorf("expected the first chunk to be handed over after its 2 requests, got request counts %v", requestCounts)
+	}
+
+	// stopping the translation leaves the remaining chunks unrequested
+	stopAt := handed[1].CLZ_ID
+	requestCount = 0
+	handedCount := 0
+	TranslateCLZ(bytes.NewReader(data), TranslateOptions{
+		IGDBSupplement: true,
+		OnGame: func(game domain.Game) bool {
+			handedCount++
+			return game.CLZ_ID != stopAt
+		},
+	})
+
+	if handedCount != 2 || requestCount != 2 {
+		t.Errorf("expected the translation to stop at the second game after 2 requests, got %d games after %d requests", handedCount, requestCount)
+	}
+
+	// the input is decoded a chunk at a time, so the games before a malformed entry are handed over
+	games := data[:bytes.LastIndex(data, []byte("</gamelist>"))]
+	malformed := io.MultiReader(bytes.NewReader(games), strings.NewReader("<game><title>Broken</gamelist>"))
+	var malformedHanded int
+	_, err = TranslateCLZ(malformed, TranslateOptions{
+		OnGame: func(game domain.Game) bool {
+			malformedHanded++
+			return true
+		},
+	})
+
+	var malformedErr *MalformedXMLError
+	if !errors.As(err, &malformedErr) || malformedHanded != 6 {
+		t.Errorf("expected the 6 games of the complete chunks to be handed over before a MalformedXMLError, got %d, %v", malformedHanded, err)
+	}
+}
+
+func TestTranslateCLZRetriesExhausted(t *testing.T) {
+	rateLimitedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer rateLimitedServer.Close()
+
+	t.Setenv("IGDB_BASE_URL", rateLimitedServer.URL)
+	t.Setenv("IGDB_API_MAX_ATTEMPTS", "2")
+
+	input := "<gamelist><game><title>1Xtreme</title><platform><displayname>PlayStation</displayname></platform></game></gamelist>"
+
+	collection, err := TranslateCLZ(strings.NewReader(input), TranslateOptions{IGDBSupplement: true})
+
+	var report *DiagnosticsReport
+	if !errors.As(err, &report) || len(report.Diagnostics) != 1 {
+		t.Fatalf("expected a DiagnosticsReport for the game, got %v", err)
+	}
+
+	var requestErr *igdb.RequestError
+	if !errors.As(report.Diagnostics[0].Err, &requestErr) {
+		t.Fatalf("expected the diagnostic to hold a RequestError, got %v", report.Diagnostics[0].Err)
+	}
+
+	if requestErr.StatusCode != http.StatusTooManyRequests || requestErr.Attempts != 2 {
+		t.Errorf("expected status 429 after 2 attempts, got status %d after %d attempts", requestErr.StatusCode, requestErr.Attempts)
+	}
+
+	if len(collection.Games) != 1 {
+		t.Errorf("expected 1 game, got %d", len(collection.Games))
+	}
+}
+
+func TestApplyIGDBDataReleaseDate(t *testing.T) {
+	tests := []struct {
+		name             string
+		firstReleaseDate int
+		expected         time.Time
+	}{
+		{name: "known release date", firstReleaseDate: 591753600, expected: time.Unix(591753600, 0)},
+		{name: "unknown release date", firstReleaseDate: 0, expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := domain.Game{Title: "Super Mario Bros. 3"}
+			applyIGDBData(&game, igdb.IGDBGameData{ID: 3, First_release_date: tt.firstReleaseDate})
+
+			if !game.FirstReleaseDate.Equal(tt.expected) {
+				t.Errorf("expected first release date %v, got %v", tt.expected, game.FirstReleaseDate)
+			}
+		})
+	}
+}
+
+func TestGenerateBatchQueries(t *testing.T) {
+	games := []domain.Game{{IGDB_ID: 0}, {IGDB_ID: 7}, {IGDB_ID: 7}}
+	for id := 1; id <= 1100; id++ {
+		games = append(games, domain.Game{IGDB_ID: 1000 + id})
+	}
+
+	batches := generateBatchQueries(games)
+
+	batchSizes := []int{}
+	for _, batch := range batches {
+		batchSizes = append(batchSizes, len(batch))
+	}
+
+	// 1101 distinct matched IDs, unmatched games left out
+	if !reflect.DeepEqual(batchSizes, []int{500, 500, 101}) {
+		t.Errorf("expected batches of 500, 500 and 101 IDs, got %v", batchSizes)
+	}
+
+	if batches[0][0] != 7 || batches[0][1] != 1001 {
+		t.Errorf("expected the first batch to start with IDs 7 and 1001, got %v", batches[0][:2])
+	}
+
+	if len(generateBatchQueries([]domain.Game{{IGDB_ID: 0}})) != 0 {
+		t.Errorf("expected no batches for unmatched games")
+	}
 }
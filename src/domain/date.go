@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DatePrecision describes how much of a Date is actually known.
+type DatePrecision string
+
+// The constants defined below act as an enumeration of the supported date precisions.
+const (
+	DatePrecisionNone  DatePrecision = ""
+	DatePrecisionYear  DatePrecision = "year"
+	DatePrecisionMonth DatePrecision = "month"
+	DatePrecisionDay   DatePrecision = "day"
+)
+
+// Date is a calendar date which may only be partially known, such as a release
+// date recorded as "1998" or "January 1990". The unknown parts of Time are set
+// to their earliest value and Precision records which parts are meaningful.
+type Date struct {
+	Time      time.Time
+	Precision DatePrecision
+}
+
+var dateLayouts = map[DatePrecision]string{
+	DatePrecisionYear:  "2006",
+	DatePrecisionMonth: "2006-01",
+	DatePrecisionDay:   "2006-01-02",
+}
+
+// NewDate builds a Date from its year, month and day parts. A month or day of
+// zero marks that part, and anything after it, as unknown.
+//
+// Parameters:
+//   - year: The year of the date, zero if the date is unknown.
+//   - month: The month of the year (1-12), zero if unknown.
+//   - day: The day of the month, zero if unknown.
+//
+// Returns:
+//   - A Date with the precision implied by the provided parts.
+func NewDate(year int, month int, day int) Date {
+	switch {
+	case year == 0:
+		return Date{}
+	case month == 0:
+		return Date{Time: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), Precision: DatePrecisionYear}
+	case day == 0:
+		return Date{Time: time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), Precision: DatePrecisionMonth}
+	default:
+		return Date{Time: time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), Precision: DatePrecisionDay}
+	}
+}
+
+// ParseDate parses a date in the format produced by Date.String, i.e. one of
+// "2006", "2006-01" or "2006-01-02". An empty string yields the zero Date.
+//
+// Parameters:
+//   - value: The date string to parse.
+//
+// Returns:
+//   - The parsed Date.
+//   - error: An error if the value does not match any of the supported formats.
+func ParseDate(value string) (Date, error) {
+	if value == "" {
+		return Date{}, nil
+	}
+
+	for _, precision := range []DatePrecision{DatePrecisionDay, DatePrecisionMonth, DatePrecisionYear} {
+		parsed, err := time.Parse(dateLayouts[precision], value)
+		if err == nil {
+			return Date{Time: parsed, Precision: precision}, nil
+		}
+	}
+
+	return Date{}, fmt.Errorf("invalid date %q", value)
+}
+
+// IsZero reports whether the date is unknown.
+func (d Date) IsZero() bool {
+	return d.Precision == DatePrecisionNone
+}
+
+// Year returns the year of the date, or zero if the date is unknown.
+func (d Date) Year() int {
+	if d.IsZero() {
+		return 0
+	}
+
+	return d.Time.Year()
+}
+
+// String formats the date to its known precision, e.g. "1998", "1998-01" or
+// "1998-01-01". The zero Date formats as an empty string.
+func (d Date) String() string {
+	layout, ok := dateLayouts[d.Precision]
+	if !ok {
+		return ""
+	}
+
+	return d.Time.Format(layout)
+}
+
+// MarshalJSON encodes the date as its String form, or null when unknown.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a date previously encoded with MarshalJSON.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == nil {
+		*d = Date{}
+		return nil
+	}
+
+	parsed, err := ParseDate(*value)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDateJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		date     Date
+		expected string
+	}{
+		{NewDate(1998, 0, 0), `"1998"`},
+		{NewDate(1998, 1, 0), `"1998-01"`},
+		{NewDate(1998, 1, 1), `"1998-01-01"`},
+		{Date{}, `null`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.date)
+		if err != nil {
+			t.Fatalf("error marshalling %#v: %v", test.date, err)
+		}
+
+		if string(data) != test.expected {
+			t.Errorf("Expected %s, but got %s", test.expected, string(data))
+		}
+
+		var decoded Date
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("error unmarshalling %s: %v", string(data), err)
+		}
+
+		if decoded != test.date {
+			t.Errorf("Expected %#v, but got %#v", test.date, decoded)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GameRef identifies a game in a collection diff.
+//
+// Fields:
+//   - Key: The key the game was matched by, see GameKey.
+//   - Title: The title of the game.
+//   - Platform: The platform of the game.
+type GameRef struct {
+	Key      string   `json:"key"`
+	Title    string   `json:"title"`
+	Platform Platform `json:"platform"`
+}
+
+// FieldChange is a changed field of a game, named after its JSON output key.
+// Nested fields are named by their path, e.g. completeness.has_box.
+//
+// Fields:
+//   - Field: The JSON path of the field.
+//   - Old: The value in the old collection, nil if it was empty.
+//   - New: The value in the new collection, nil if it is empty.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// GameChanges are the changed fields of a game present in both collections.
+type GameChanges struct {
+	GameRef
+	Changes []FieldChange `json:"changes"`
+}
+
+// CollectionDiff is the difference between two snapshots of a collection.
+//
+// Fields:
+//   - Added: The games only in the new collection.
+//   - Removed: The games only in the old collection.
+//   - Modified: The games in both collections with changed fields.
+type CollectionDiff struct {
+	Added    []GameRef     `json:"added"`
+	Removed  []GameRef     `json:"removed"`
+	Modified []GameChanges `json:"modified"`
+}
+
+// Empty reports whether the collections hold the same games.
+func (d CollectionDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// GameKey returns the key games are matched by across snapshots: the CLZ ID,
+// otherwise the CLZ Core bpgameid, otherwise the title and platform.
+func GameKey(game Game) string {
+	switch {
+	case game.CLZ_ID != 0:
+		return "clz:" + strconv.Itoa(game.CLZ_ID)
+	case game.BPGameID != 0:
+		return "bpgameid:" + strconv.Itoa(game.BPGameID)
+	default:
+		return "title:" + strings.ToLower(game.Title) + "|" + strings.ToLower(string(game.Platform))
+	}
+}
+
+// DiffGames compares two snapshots of a collection. Games are matched by
+// GameKey and compared field by field on their JSON output.
+//
+// Parameters:
+//   - oldGames: The games of the older snapshot.
+//   - newGames: The games of the newer snapshot.
+//
+// Returns:
+//   - The CollectionDiff of the snapshots, each list sorted by title.
+//   - error: An error if a game cannot be encoded for comparison, otherwise nil.
+func DiffGames(oldGames []Game, newGames []Game) (CollectionDiff, error) {
+	diff := CollectionDiff{Added: []GameRef{}, Removed: []GameRef{}, Modified: []GameChanges{}}
+
+	oldByKey := map[string]Game{}
+	for _, game := range oldGames {
+		oldByKey[GameKey(game)] = game
+	}
+
+	newKeys := map[string]bool{}
+	for _, game := range newGames {
+		key := GameKey(game)
+		newKeys[key] = true
+		ref := GameRef{Key: key, Title: game.Title, Platform: game.Platform}
+
+		oldGame, ok := oldByKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, ref)
+			continue
+		}
+
+		changes, err := diffFields(oldGame, game)
+		if err != nil {
+			return CollectionDiff{}, err
+		}
+		if len(changes) > 0 {
+			diff.Modified = append(diff.Modified, GameChanges{GameRef: ref, Changes: changes})
+		}
+	}
+
+	for _, game := range oldGames {
+		key := GameKey(game)
+		if !newKeys[key] {
+			diff.Removed = append(diff.Removed, GameRef{Key: key, Title: game.Title, Platform: game.Platform})
+			// a game listed twice is only removed once
+			newKeys[key] = true
+		}
+	}
+
+	sortRefs := func(refs []GameRef) {
+		sort.SliceStable(refs, func(i, j int) bool {
+			return strings.ToLower(refs[i].Title) < strings.ToLower(refs[j].Title)
+		})
+	}
+	sortRefs(diff.Added)
+	sortRefs(diff.Removed)
+	sort.SliceStable(diff.Modified, func(i, j int) bool {
+		return strings.ToLower(diff.Modified[i].Title) < strings.ToLower(diff.Modified[j].Title)
+	})
+
+	return diff, nil
+}
+
+// diffFields returns the changed fields of the game, sorted by field.
+func diffFields(oldGame Game, newGame Game) ([]FieldChange, error) {
+	oldFields, err := flattenGame(oldGame)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := flattenGame(newGame)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]bool{}
+	for field := range oldFields {
+		fields[field] = true
+	}
+	for field := range newFields {
+		fields[field] = true
+	}
+
+	changes := []FieldChange{}
+	for field := range fields {
+		if !reflect.DeepEqual(oldFields[field], newFields[field]) {
+			changes = append(changes, FieldChange{Field: field, Old: oldFields[field], New: newFields[field]})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+// flattenGame returns the JSON output of the game as a map of JSON paths to
+// values. Objects are flattened, lists are kept as values.
+func flattenGame(game Game) (map[string]interface{}, error) {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	var flatten func(prefix string, object map[string]interface{})
+	flatten = func(prefix string, object map[string]interface{}) {
+		for key, value := range object {
+			if nested, ok := value.(map[string]interface{}); ok {
+				flatten(prefix+key+".", nested)
+				continue
+			}
+			if value != nil {
+				fields[prefix+key] = value
+			}
+		}
+	}
+	flatten("", decoded)
+
+	return fields, nil
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGameKey(t *testing.T) {
+	tests := []struct {
+		game     Game
+		expected string
+	}{
+		{Game{CLZ_ID: 2474, BPGameID: 9, Title: "8 Eyes"}, "clz:2474"},
+		{Game{BPGameID: 9, Title: "8 Eyes"}, "bpgameid:9"},
+		{Game{Title: "8 Eyes", Platform: NES}, "title:8 eyes|nes"},
+	}
+
+	for _, tt := range tests {
+		if actual := GameKey(tt.game); actual != tt.expected {
+			t.Errorf("Expected key %s, but got %s", tt.expected, actual)
+		}
+	}
+}
+
+func TestDiffGames(t *testing.T) {
+	mario := Game{
+		CLZ_ID: 1, Title: "Super Mario Bros. 3", Platform: NES, Condition: "Good", Quantity: 1,
+		Completeness: Completeness{HasGame: true}, PricechartingValue: 12.5,
+	}
+	eyes := Game{CLZ_ID: 2474, Title: "8 Eyes", Platform: NES}
+	tokobot := Game{BPGameID: 77, Title: "Tokobot", Platform: PSP}
+
+	updatedMario := mario
+	updatedMario.Condition = "Mint"
+	updatedMario.Quantity = 2
+	updatedMario.Completeness.HasBox = true
+	updatedMario.PricechartingValue = 0
+
+	diff, err := DiffGames([]Game{mario, eyes}, []Game{tokobot, updatedMario, eyes})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	expectedAdded := []GameRef{{Key: "bpgameid:77", Title: "Tokobot", Platform: PSP}}
+	if !reflect.DeepEqual(diff.Added, expectedAdded) {
+		t.Errorf("Expected added %v, but got %v", expectedAdded, diff.Added)
+	}
+	if len(diff.Removed) != 0 {
+		t.Errorf("Expected no removed games, but got %v", diff.Removed)
+	}
+
+	expectedChanges := []FieldChange{
+		{Field: "completeness.has_box", Old: false, New: true},
+		{Field: "condition", Old: "Good", New: "Mint"},
+		{Field: "pricecharting_value", Old: 12.5, New: nil},
+		{Field: "quantity", Old: float64(1), New: float64(2)},
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].Key != "clz:1" {
+		t.Fatalf("Expected only Super Mario Bros. 3 to be modified, but got %v", diff.Modified)
+	}
+	if !reflect.DeepEqual(diff.Modified[0].Changes, expectedChanges) {
+		t.Errorf("Expected changes %v, but got %v", expectedChanges, diff.Modified[0].Changes)
+	}
+
+	diff, err = DiffGames([]Game{mario, eyes}, []Game{eyes})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Title != mario.Title || len(diff.Added) != 0 {
+		t.Errorf("Expected Super Mario Bros. 3 to be removed, but got %v", diff)
+	}
+
+	diff, _ = DiffGames([]Game{mario, eyes}, []Game{eyes, mario})
+	if !diff.Empty() {
+		t.Errorf("Expected no differences between the same games, but got %v", diff)
+	}
+}
@@ -4,63 +4,166 @@ import (
 	"time"
 )
 
+// SchemaVersion is the version of the JSON output schema. It is bumped whenever
+// a field of the output is added, renamed, removed or changes type.
+const SchemaVersion = 1
+
+// GameCollection is the translated collection as written to the JSON output.
+//
+// Fields:
+//   - SchemaVersion: The SchemaVersion the collection was written with.
+//   - Games: The games of the collection.
 type GameCollection struct {
-	Games []Game
+	SchemaVersion int    `json:"schema_version"`
+	Games         []Game `json:"games"`
 }
 
 // Game is the domain model for a video game as defined for our purposes.
+// Empty strings, numbers, lists and maps are omitted from its JSON output,
+// while flags, nested objects, timestamps and the identifying CLZ_ID, Title and
+// Platform are always present.
 type Game struct {
-	Boxset             bool
-	Completeness       Completeness
-	Condition          string
-	Cover              Cover
-	DateAcquired       time.Time
-	Developers         []string
-	Edition            string
-	FirstReleaseDate   time.Time
-	Format             string
-	Genres             []string
-	HardwareType       string
-	IGDB_ID            int
-	Links              []Link
-	Multiplayer        bool
-	Platform           Platform
-	PricechartingValue float64
-	Publishers         []string
-	Quantity           int
-	Region             string
-	ReleaseDate        time.Time
-	Series             string
-	Storyline          string
-	Summary            string
-	Title              string
+	Artworks           []Artwork    `json:"artworks,omitempty"`
+	AudienceRating     string       `json:"audience_rating,omitempty"`
+	BPGameID           int          `json:"bpgameid,omitempty"`
+	Boxset             bool         `json:"boxset"`
+	CLZImageFiles      CLZImages    `json:"clz_image_files"`
+	CLZImages          CLZImages    `json:"clz_images"`
+	CLZ_ID             int          `json:"clz_id"`
+	Completeness       Completeness `json:"completeness"`
+	Condition          string       `json:"condition,omitempty"`
+	Cover              Cover        `json:"cover"`
+	DateAcquired       time.Time    `json:"date_acquired"`
+	Developers         []string     `json:"developers,omitempty"`
+	DiskCount          int          `json:"disk_count,omitempty"`
+	Edition            string       `json:"edition,omitempty"`
+	FirstReleaseDate   time.Time    `json:"first_release_date"`
+	Format             string       `json:"format,omitempty"`
+	Franchises         []string     `json:"franchises,omitempty"`
+	Genres             []string     `json:"genres,omitempty"`
+	HardwareType       string       `json:"hardware_type,omitempty"`
+	IGDB_ID            int          `json:"igdb_id,omitempty"`
+	Language           string       `json:"language,omitempty"`
+	LastModified       time.Time    `json:"last_modified"`
+	Links              []Link       `json:"links,omitempty"`
+	Loans              []Loan       `json:"loans,omitempty"`
+	Location           string       `json:"location,omitempty"`
+	Multiplayer        bool         `json:"multiplayer"`
+	MyRating           float64      `json:"my_rating,omitempty"`
+	Notes              string       `json:"notes,omitempty"`
+	Owner              string       `json:"owner,omitempty"`
+	Platform           Platform     `json:"platform"`
+	PricechartingCIB   float64      `json:"pricecharting_cib,omitempty"`
+	PricechartingLoose float64      `json:"pricecharting_loose,omitempty"`
+	PricechartingNew   float64      `json:"pricecharting_new,omitempty"`
+	PricechartingURL   string       `json:"pricecharting_url,omitempty"`
+	PricechartingValue float64      `json:"pricecharting_value,omitempty"`
+	Provenance         Provenance   `json:"provenance"`
+	Publishers         []string     `json:"publishers,omitempty"`
+	PurchaseDate       Date         `json:"purchase_date"`
+	PurchasePrice      float64      `json:"purchase_price,omitempty"`
+	Quantity           int          `json:"quantity,omitempty"`
+	Region             string       `json:"region,omitempty"`
+	ReleaseDate        Date         `json:"release_date"`
+	Screenshots        []Artwork    `json:"screenshots,omitempty"`
+	Series             string       `json:"series,omitempty"`
+	Storyline          string       `json:"storyline,omitempty"`
+	Store              string       `json:"store,omitempty"`
+	Summary            string       `json:"summary,omitempty"`
+	Tags               []string     `json:"tags,omitempty"`
+	Title              string       `json:"title"`
+	UPC                string       `json:"upc,omitempty"`
+	Videos             []Video      `json:"videos,omitempty"`
 }
 
+// Cover is the cover image of a game from IGDB. LocalPath is set once the
+// image has been downloaded.
 type Cover struct {
-	ID    int
-	Width int
-	URL   string
+	ID        int    `json:"id,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	URL       string `json:"url,omitempty"`
+	LocalPath string `json:"local_path,omitempty"`
+}
+
+// Artwork is an artwork or screenshot image of a game from IGDB. LocalPath is
+// set once the image has been downloaded.
+type Artwork struct {
+	ID        int    `json:"id,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	URL       string `json:"url,omitempty"`
+	LocalPath string `json:"local_path,omitempty"`
+}
+
+// Video is a video of a game from IGDB, hosted on YouTube.
+type Video struct {
+	Name    string `json:"name,omitempty"`
+	VideoID string `json:"video_id"`
 }
 
 type Completeness struct {
-	HasBox    bool
-	HasManual bool
-	HasGame   bool
+	HasBox    bool `json:"has_box"`
+	HasManual bool `json:"has_manual"`
+	HasGame   bool `json:"has_game"`
+}
+
+// CLZImages holds the paths of the images CLZ stores locally for a game. In
+// Game.CLZImages they are recorded as on the machine the collection was
+// exported from, in Game.CLZImageFiles they are the imported local copies.
+type CLZImages struct {
+	Backdrop   string `json:"backdrop,omitempty"`
+	BackCover  string `json:"back_cover,omitempty"`
+	FrontCover string `json:"front_cover,omitempty"`
+	Thumbnail  string `json:"thumbnail,omitempty"`
+}
+
+// Loan records a game lent out to someone.
+type Loan struct {
+	DueDate    time.Time `json:"due_date"`
+	LoanDate   time.Time `json:"loan_date"`
+	Loaner     string    `json:"loaner"`
+	Notes      string    `json:"notes,omitempty"`
+	ReturnDate time.Time `json:"return_date"`
 }
 
 type Link struct {
-	Description string
-	URL         string
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
 }
 
 type Platform string
 
 // Platform represents a type for various gaming platforms.
-// The constants defined below act as an enumeration of different PlayStation platforms.
+// The constants defined below act as an enumeration of common platforms, named as
+// in CLZ. The full set of known platforms is defined by PlatformMap.
 const (
-	PlayStation  Platform = "PlayStation"
-	PlayStation2 Platform = "PlayStation 2"
-	PlayStation3 Platform = "PlayStation 3"
-	PlayStation4 Platform = "PlayStation 4"
-	PlayStation5 Platform = "PlayStation 5"
+	Atari2600       Platform = "Atari 2600/VCS"
+	Dreamcast       Platform = "Dreamcast"
+	Famicom         Platform = "Family Computer / Famicom"
+	GameBoy         Platform = "Game Boy"
+	GameBoyAdvance  Platform = "Game Boy Advance"
+	GameBoyColor    Platform = "Game Boy Color"
+	GameCube        Platform = "GameCube"
+	GameGear        Platform = "Game Gear"
+	Genesis         Platform = "Genesis / Mega Drive"
+	MasterSystem    Platform = "Master System"
+	NES             Platform = "NES"
+	Nintendo3DS     Platform = "Nintendo 3DS"
+	Nintendo64      Platform = "Nintendo 64"
+	NintendoDS      Platform = "Nintendo DS"
+	NintendoSwitch  Platform = "Nintendo Switch"
+	PlayStation     Platform = "PlayStation"
+	PlayStation2    Platform = "PlayStation 2"
+	PlayStation3    Platform = "PlayStation 3"
+	PlayStation4    Platform = "PlayStation 4"
+	PlayStation5    Platform = "PlayStation 5"
+	PlayStationVita Platform = "PlayStation Vita"
+	PSP             Platform = "PSP"
+	Saturn          Platform = "Saturn"
+	SNES            Platform = "SNES"
+	SuperFamicom    Platform = "Super Famicom"
+	Wii             Platform = "Wii"
+	WiiU            Platform = "Wii U"
+	Xbox            Platform = "Xbox"
+	Xbox360         Platform = "Xbox 360"
+	XboxOne         Platform = "Xbox One"
 )
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"strings"
+)
+
+// MatchOverride pins a CLZ game to a specific IGDB game, or excludes it from
+// IGDB enrichment altogether. A game is identified by the first non-empty key
+// of CLZ_ID, BPGameID or Title and Platform.
+//
+// Fields:
+//   - CLZ_ID: The CLZ database ID of the game.
+//   - BPGameID: The CLZ Core game ID of the game.
+//   - Title: The CLZ title of the game, matched case-insensitively together with Platform.
+//   - Platform: The CLZ platform of the game.
+//   - IGDB_ID: The IGDB game ID to use for the game.
+//   - Skip: Whether the game should not be enriched with IGDB data.
+type MatchOverride struct {
+	CLZ_ID   int      `json:"clz_id,omitempty"`
+	BPGameID int      `json:"bpgameid,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Platform Platform `json:"platform,omitempty"`
+	IGDB_ID  int      `json:"igdb_id,omitempty"`
+	Skip     bool     `json:"skip,omitempty"`
+}
+
+// MatchOverrides is a list of manual IGDB match decisions.
+type MatchOverrides []MatchOverride
+
+// Matches reports whether the override applies to the game.
+func (o MatchOverride) Matches(game Game) bool {
+	switch {
+	case o.CLZ_ID != 0:
+		return o.CLZ_ID == game.CLZ_ID
+	case o.BPGameID != 0:
+		return o.BPGameID == game.BPGameID
+	case o.Title != "":
+		return strings.EqualFold(strings.TrimSpace(o.Title), strings.TrimSpace(game.Title)) && o.Platform == game.Platform
+	default:
+		return false
+	}
+}
+
+// Find returns the override for the game. Overrides keyed by CLZ ID take
+// precedence over those keyed by CLZ Core game ID, which take precedence
+// over those keyed by title and platform.
+//
+// Parameters:
+//   - game: The game to find an override for.
+//
+// Returns:
+//   - The matching MatchOverride.
+//   - A boolean indicating whether an override was found.
+func (o MatchOverrides) Find(game Game) (MatchOverride, bool) {
+	var best MatchOverride
+	bestRank := 0
+
+	for _, override := range o {
+		if !override.Matches(game) {
+			continue
+		}
+
+		rank := 1
+		switch {
+		case override.CLZ_ID != 0:
+			rank = 3
+		case override.BPGameID != 0:
+			rank = 2
+		}
+
+		if rank > bestRank {
+			best, bestRank = override, rank
+		}
+	}
+
+	return best, bestRank > 0
+}
+
+// Set adds the override, replacing any existing override with the same key.
+//
+// Parameters:
+//   - override: The MatchOverride to add.
+//
+// Returns:
+//   - The updated MatchOverrides.
+func (o MatchOverrides) Set(override MatchOverride) MatchOverrides {
+	for i, existing := range o {
+		if existing.CLZ_ID == override.CLZ_ID && existing.BPGameID == override.BPGameID &&
+			strings.EqualFold(existing.Title, override.Title) && existing.Platform == override.Platform {
+			o[i] = override
+			return o
+		}
+	}
+
+	return append(o, override)
+}
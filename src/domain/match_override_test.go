@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestMatchOverridesFind(t *testing.T) {
+	overrides := MatchOverrides{
+		{Title: "1Xtreme (Greatest Hits)", Platform: PlayStation, IGDB_ID: 1},
+		{BPGameID: 28697, IGDB_ID: 2},
+		{CLZ_ID: 812, IGDB_ID: 3},
+		{CLZ_ID: 999, Skip: true},
+	}
+
+	tests := []struct {
+		game     Game
+		expected int
+		found    bool
+	}{
+		{Game{CLZ_ID: 812, BPGameID: 28697, Title: "1Xtreme (Greatest Hits)", Platform: PlayStation}, 3, true},
+		{Game{CLZ_ID: 813, BPGameID: 28697, Title: "1Xtreme (Greatest Hits)", Platform: PlayStation}, 2, true},
+		{Game{CLZ_ID: 814, Title: "1xtreme (greatest hits)", Platform: PlayStation}, 1, true},
+		{Game{CLZ_ID: 815, Title: "1Xtreme (Greatest Hits)", Platform: PlayStation2}, 0, false},
+	}
+
+	for _, test := range tests {
+		override, found := overrides.Find(test.game)
+		if found != test.found || override.IGDB_ID != test.expected {
+			t.Errorf("Expected IGDB_ID %d (found %v) for %+v, but got %d (found %v)", test.expected, test.found, test.game, override.IGDB_ID, found)
+		}
+	}
+
+	updated := overrides.Set(MatchOverride{CLZ_ID: 999, IGDB_ID: 4})
+	if len(updated) != len(overrides) {
+		t.Errorf("Expected Set to replace the existing override, but got %d overrides", len(updated))
+	}
+
+	if override, _ := updated.Find(Game{CLZ_ID: 999}); override.IGDB_ID != 4 || override.Skip {
+		t.Errorf("Expected replaced override to pin IGDB_ID 4, but got %+v", override)
+	}
+}
@@ -1,57 +1,177 @@
 package domain
 
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//go:embed platforms.json
+var embeddedPlatforms []byte
+
+// PlatformDefinition describes a CLZ platform and the IGDB platform it maps to.
+//
+// Fields:
+//   - CLZ: The canonical CLZ platform name.
+//   - IGDB_ID: The IGDB platform ID.
+//   - Aliases: Alternative names for the platform, e.g. "Mega Drive" for "Genesis / Mega Drive".
+type PlatformDefinition struct {
+	CLZ     string   `json:"clz"`
+	IGDB_ID int      `json:"igdb_id"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// PlatformMapping maps CLZ platform names, including aliases, to IGDB platform
+// IDs and IGDB platform IDs back to canonical CLZ platform names.
 type PlatformMapping struct {
 	CLZToIGDB map[string]int
 	IGDBToCLZ map[int]string
 }
 
-var PlatformMap = PlatformMapping{
-	CLZToIGDB: map[string]int{
-		"Atari 2600/VCS":            59,
-		"Family Computer / Famicom": 99,
-		"Super Famicom":             58,
-		"NES":                       18,
-		"SNES":                      19,
-		"Nintendo 64":               4,
-		"Wii":                       5,
-		"Nintendo Switch":           130,
-		"Saturn":                    32,
-		"Dreamcast":                 23,
-		"Genesis / Mega Drive":      29,
-		"Game Boy":                  33,
-		"Game Boy Advance":          24,
-		"Game Gear":                 35,
-		"PlayStation":               7,
-		"PlayStation 2":             8,
-		"PlayStation 3":             9,
-		"PlayStation 4":             48,
-		"PlayStation 5":             167,
-		"PlayStation Vita":          46,
-		"PSP":                       38,
-		"Xbox 360":                  12,
-	},
-	IGDBToCLZ: map[int]string{
-		59:  "Atari 2600/VCS",
-		99:  "Family Computer / Famicom",
-		58:  "Super Famicom",
-		18:  "NES",
-		19:  "SNES",
-		4:   "Nintendo 64",
-		5:   "Wii",
-		130: "Nintendo Switch",
-		32:  "Saturn",
-		23:  "Dreamcast",
-		29:  "Genesis / Mega Drive",
-		33:  "Game Boy",
-		24:  "Game Boy Advance",
-		35:  "Game Gear",
-		7:   "PlayStation",
-		8:   "PlayStation 2",
-		9:   "PlayStation 3",
-		48:  "PlayStation 4",
-		167: "PlayStation 5",
-		46:  "PlayStation Vita",
-		38:  "PSP",
-		12:  "Xbox 360",
-	},
+// platformDefinitions holds the definitions PlatformMap is currently built from.
+var platformDefinitions = mustParsePlatformDefinitions(embeddedPlatforms)
+
+// PlatformMap is the platform mapping built from the embedded platform data
+// file, extended by LoadPlatformExtensionFile.
+var PlatformMap = mustNewPlatformMapping(platformDefinitions)
+
+func mustParsePlatformDefinitions(data []byte) []PlatformDefinition {
+	var definitions []PlatformDefinition
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		panic(fmt.Sprintf("invalid embedded platform data: %v", err))
+	}
+
+	return definitions
+}
+
+func mustNewPlatformMapping(definitions []PlatformDefinition) PlatformMapping {
+	mapping, err := NewPlatformMapping(definitions)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded platform data: %v", err))
+	}
+
+	return mapping
+}
+
+// NewPlatformMapping builds a PlatformMapping from platform definitions. The
+// reverse IGDB to CLZ map is derived from the canonical CLZ names, so every
+// IGDB platform ID may only be used by a single definition and every name or
+// alias may only refer to a single IGDB platform.
+//
+// Parameters:
+//   - definitions: The platform definitions to build the mapping from.
+//
+// Returns:
+//   - PlatformMapping: The mapping between CLZ platform names and IGDB platform IDs.
+//   - error: An error if the definitions are incomplete or conflict, otherwise nil.
+func NewPlatformMapping(definitions []PlatformDefinition) (PlatformMapping, error) {
+	mapping := PlatformMapping{
+		CLZToIGDB: map[string]int{},
+		IGDBToCLZ: map[int]string{},
+	}
+
+	for _, definition := range definitions {
+		if definition.CLZ == "" || definition.IGDB_ID <= 0 {
+			return PlatformMapping{}, fmt.Errorf("platform definition %+v needs a CLZ name and IGDB ID", definition)
+		}
+
+		if existing, ok := mapping.IGDBToCLZ[definition.IGDB_ID]; ok {
+			return PlatformMapping{}, fmt.Errorf("IGDB platform %d is mapped by both %q and %q, use aliases instead", definition.IGDB_ID, existing, definition.CLZ)
+		}
+		mapping.IGDBToCLZ[definition.IGDB_ID] = definition.CLZ
+
+		for _, name := range append([]string{definition.CLZ}, definition.Aliases...) {
+			if existing, ok := mapping.CLZToIGDB[name]; ok && existing != definition.IGDB_ID {
+				return PlatformMapping{}, fmt.Errorf("platform name %q is mapped to both IGDB platform %d and %d", name, existing, definition.IGDB_ID)
+			}
+			mapping.CLZToIGDB[name] = definition.IGDB_ID
+		}
+	}
+
+	return mapping, nil
+}
+
+// IGDBPlatformID returns the IGDB platform ID for a CLZ platform name or alias,
+// falling back to a case-insensitive comparison when there is no exact match.
+//
+// Parameters:
+//   - name: The CLZ platform name.
+//
+// Returns:
+//   - The IGDB platform ID.
+//   - A boolean indicating whether the platform is mapped.
+func (m PlatformMapping) IGDBPlatformID(name string) (int, bool) {
+	if id, ok := m.CLZToIGDB[name]; ok {
+		return id, true
+	}
+
+	for clzName, id := range m.CLZToIGDB {
+		if strings.EqualFold(clzName, strings.TrimSpace(name)) {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// mergePlatformDefinitions applies extension definitions on top of base ones.
+// An extension with the CLZ name of a base definition replaces its IGDB ID and
+// adds its aliases, any other extension is added as a new platform.
+func mergePlatformDefinitions(base []PlatformDefinition, extension []PlatformDefinition) []PlatformDefinition {
+	merged := append([]PlatformDefinition{}, base...)
+
+	for _, definition := range extension {
+		replaced := false
+		for i, existing := range merged {
+			if existing.CLZ != definition.CLZ {
+				continue
+			}
+
+			merged[i].IGDB_ID = definition.IGDB_ID
+			merged[i].Aliases = append(append([]string{}, existing.Aliases...), definition.Aliases...)
+			replaced = true
+			break
+		}
+
+		if !replaced {
+			merged = append(merged, definition)
+		}
+	}
+
+	return merged
+}
+
+// LoadPlatformExtensionFile extends PlatformMap with platform definitions read
+// from a JSON file in the same format as the embedded platform data, e.g.
+//
+//	[{"clz": "Sega Pico", "igdb_id": 339, "aliases": ["Pico"]}]
+//
+// Parameters:
+//   - filePath: The path to the platform extension file.
+//
+// Returns:
+//   - error: An error if the file cannot be read or conflicts with the existing
+//     platforms, in which case PlatformMap is left unchanged.
+func LoadPlatformExtensionFile(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	var extension []PlatformDefinition
+	if err := json.Unmarshal(data, &extension); err != nil {
+		return fmt.Errorf("invalid platform extension file %s: %w", filePath, err)
+	}
+
+	merged := mergePlatformDefinitions(platformDefinitions, extension)
+	mapping, err := NewPlatformMapping(merged)
+	if err != nil {
+		return fmt.Errorf("invalid platform extension file %s: %w", filePath, err)
+	}
+
+	platformDefinitions = merged
+	PlatformMap = mapping
+	return nil
 }
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlatformMappingRoundTrip(t *testing.T) {
+	for _, definition := range platformDefinitions {
+		igdbID, ok := PlatformMap.CLZToIGDB[definition.CLZ]
+		if !ok || igdbID != definition.IGDB_ID {
+			t.Errorf("Expected %q to map to IGDB platform %d, but got %d", definition.CLZ, definition.IGDB_ID, igdbID)
+		}
+
+		if PlatformMap.IGDBToCLZ[igdbID] != definition.CLZ {
+			t.Errorf("Expected IGDB platform %d to map back to %q, but got %q", igdbID, definition.CLZ, PlatformMap.IGDBToCLZ[igdbID])
+		}
+
+		for _, alias := range definition.Aliases {
+			if PlatformMap.IGDBToCLZ[PlatformMap.CLZToIGDB[alias]] != definition.CLZ {
+				t.Errorf("Expected alias %q to map back to %q, but got %q", alias, definition.CLZ, PlatformMap.IGDBToCLZ[PlatformMap.CLZToIGDB[alias]])
+			}
+		}
+	}
+
+	for igdbID, name := range PlatformMap.IGDBToCLZ {
+		if PlatformMap.CLZToIGDB[name] != igdbID {
+			t.Errorf("Expected %q to map to IGDB platform %d, but got %d", name, igdbID, PlatformMap.CLZToIGDB[name])
+		}
+	}
+
+	platforms := []Platform{
+		Atari2600, Dreamcast, Famicom, GameBoy, GameBoyAdvance, GameBoyColor, GameCube, GameGear, Genesis,
+		MasterSystem, NES, Nintendo3DS, Nintendo64, NintendoDS, NintendoSwitch, PlayStation, PlayStation2,
+		PlayStation3, PlayStation4, PlayStation5, PlayStationVita, PSP, Saturn, SNES, SuperFamicom, Wii, WiiU,
+		Xbox, Xbox360, XboxOne,
+	}
+	for _, platform := range platforms {
+		if _, ok := PlatformMap.IGDBToCLZ[PlatformMap.CLZToIGDB[string(platform)]]; !ok {
+			t.Errorf("Expected platform constant %q to be mapped", platform)
+		}
+	}
+
+	if id, ok := PlatformMap.IGDBPlatformID("mega drive"); !ok || id != 29 {
+		t.Errorf("Expected alias lookup to be case-insensitive, but got %d, %v", id, ok)
+	}
+}
+
+func TestNewPlatformMappingConflicts(t *testing.T) {
+	_, err := NewPlatformMapping([]PlatformDefinition{
+		{CLZ: "Genesis", IGDB_ID: 29},
+		{CLZ: "Mega Drive", IGDB_ID: 29},
+	})
+	if err == nil {
+		t.Errorf("Expected an error for two platforms sharing an IGDB ID")
+	}
+
+	_, err = NewPlatformMapping([]PlatformDefinition{
+		{CLZ: "NES", IGDB_ID: 18, Aliases: []string{"Famicom"}},
+		{CLZ: "Family Computer / Famicom", IGDB_ID: 99, Aliases: []string{"Famicom"}},
+	})
+	if err == nil {
+		t.Errorf("Expected an error for an alias mapped to two IGDB platforms")
+	}
+}
+
+func TestLoadPlatformExtensionFile(t *testing.T) {
+	originalDefinitions, originalMap := platformDefinitions, PlatformMap
+	defer func() {
+		platformDefinitions, PlatformMap = originalDefinitions, originalMap
+	}()
+
+	filePath := filepath.Join(t.TempDir(), "platforms.json")
+	os.WriteFile(filePath, []byte(`[
+		{"clz": "Sega Pico", "igdb_id": 339, "aliases": ["Pico"]},
+		{"clz": "NES", "igdb_id": 18, "aliases": ["Nintendo NES"]}
+	]`), 0644)
+
+	// Execution
+	err := LoadPlatformExtensionFile(filePath)
+
+	// Validation
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if PlatformMap.CLZToIGDB["Pico"] != 339 || PlatformMap.IGDBToCLZ[339] != "Sega Pico" {
+		t.Errorf("Expected extension platform to be mapped, but got %d, %q", PlatformMap.CLZToIGDB["Pico"], PlatformMap.IGDBToCLZ[339])
+	}
+
+	if PlatformMap.CLZToIGDB["Nintendo NES"] != 18 || PlatformMap.CLZToIGDB["Nintendo Entertainment System"] != 18 {
+		t.Errorf("Expected extension aliases to be merged into the existing platform")
+	}
+
+	os.WriteFile(filePath, []byte(`[{"clz": "Sega Nomad", "igdb_id": 29}]`), 0644)
+	if err := LoadPlatformExtensionFile(filePath); err == nil {
+		t.Errorf("Expected an error for an extension reusing an IGDB platform ID")
+	}
+
+	if PlatformMap.CLZToIGDB["Pico"] != 339 {
+		t.Errorf("Expected a failed extension to leave the mapping unchanged")
+	}
+}
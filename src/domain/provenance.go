@@ -0,0 +1,74 @@
+package domain
+
+import "strings"
+
+// Source identifies where a value of a game came from.
+type Source string
+
+const (
+	SourceCLZ  Source = "clz"
+	SourceIGDB Source = "igdb"
+)
+
+// Provenance records the sources of the values merged into a game's developers,
+// genres and publishers, keyed by value. It is only recorded for games
+// supplemented with IGDB data; the values of other games all come from CLZ.
+//
+// Fields:
+//   - Developers: The sources of each value in Game.Developers.
+//   - Genres: The sources of each value in Game.Genres.
+//   - Publishers: The sources of each value in Game.Publishers.
+type Provenance struct {
+	Developers map[string][]Source `json:"developers,omitempty"`
+	Genres     map[string][]Source `json:"genres,omitempty"`
+	Publishers map[string][]Source `json:"publishers,omitempty"`
+}
+
+// MergeValues merges the values IGDB lists for a game into the values from CLZ.
+// Values are compared case-insensitively, so a value listed by both keeps its
+// CLZ spelling and is attributed to both sources.
+//
+// Parameters:
+//   - clzValues: The values from CLZ, kept first and in order.
+//   - igdbValues: The values from IGDB, appended when not already present.
+//
+// Returns:
+//   - The merged values.
+//   - The sources of each merged value.
+func MergeValues(clzValues []string, igdbValues []string) ([]string, map[string][]Source) {
+	merged := []string{}
+	sources := map[string][]Source{}
+	index := map[string]string{}
+
+	add := func(value string, source Source) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return
+		}
+
+		key := strings.ToLower(value)
+		existing, ok := index[key]
+		if !ok {
+			index[key] = value
+			merged = append(merged, value)
+			sources[value] = []Source{source}
+			return
+		}
+
+		for _, s := range sources[existing] {
+			if s == source {
+				return
+			}
+		}
+		sources[existing] = append(sources[existing], source)
+	}
+
+	for _, value := range clzValues {
+		add(value, SourceCLZ)
+	}
+	for _, value := range igdbValues {
+		add(value, SourceIGDB)
+	}
+
+	return merged, sources
+}
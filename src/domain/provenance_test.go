@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeValues(t *testing.T) {
+	tests := []struct {
+		name            string
+		clzValues       []string
+		igdbValues      []string
+		expectedValues  []string
+		expectedSources map[string][]Source
+	}{
+		{
+			name:            "no values",
+			expectedValues:  []string{},
+			expectedSources: map[string][]Source{},
+		},
+		{
+			name:           "disjoint values",
+			clzValues:      []string{"Racing"},
+			igdbValues:     []string{"Sport"},
+			expectedValues: []string{"Racing", "Sport"},
+			expectedSources: map[string][]Source{
+				"Racing": {SourceCLZ},
+				"Sport":  {SourceIGDB},
+			},
+		},
+		{
+			name:           "shared values keep the CLZ spelling",
+			clzValues:      []string{"Racing", "Sports"},
+			igdbValues:     []string{"racing", " Sports ", ""},
+			expectedValues: []string{"Racing", "Sports"},
+			expectedSources: map[string][]Source{
+				"Racing": {SourceCLZ, SourceIGDB},
+				"Sports": {SourceCLZ, SourceIGDB},
+			},
+		},
+		{
+			name:           "duplicates within a source",
+			igdbValues:     []string{"Nintendo", "Nintendo"},
+			expectedValues: []string{"Nintendo"},
+			expectedSources: map[string][]Source{
+				"Nintendo": {SourceIGDB},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		values, sources := MergeValues(tt.clzValues, tt.igdbValues)
+		if !reflect.DeepEqual(values, tt.expectedValues) {
+			t.Errorf("%s: Expected values %v, but got %v", tt.name, tt.expectedValues, values)
+		}
+		if !reflect.DeepEqual(sources, tt.expectedSources) {
+			t.Errorf("%s: Expected sources %v, but got %v", tt.name, tt.expectedSources, sources)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// unknownGroup names the group of games without a value for a grouping.
+const unknownGroup = "Unknown"
+
+// Stats are the aggregates of a collection.
+//
+// Fields:
+//   - Games: The number of games.
+//   - Value: The sum of the PriceCharting values of the games.
+//   - Platforms, Regions, Formats, Genres: The games grouped by each, most games first.
+//   - Completeness: How complete the games are.
+//   - Acquisitions: The number of games acquired per month, oldest first.
+//   - AcquisitionUnknown: The number of games without an acquisition date.
+//   - Enrichment: How many games have IGDB data.
+type Stats struct {
+	Games              int                `json:"games"`
+	Value              float64            `json:"value"`
+	Platforms          []GroupStats       `json:"platforms"`
+	Regions            []GroupStats       `json:"regions"`
+	Formats            []GroupStats       `json:"formats"`
+	Genres             []GroupStats       `json:"genres"`
+	Completeness       CompletenessStats  `json:"completeness"`
+	Acquisitions       []AcquisitionStats `json:"acquisitions"`
+	AcquisitionUnknown int                `json:"acquisition_unknown"`
+	Enrichment         EnrichmentStats    `json:"enrichment"`
+}
+
+// GroupStats are the aggregates of the games sharing a platform, region,
+// format or genre. Games without a value are grouped as "Unknown".
+//
+// Fields:
+//   - Name: The shared value.
+//   - Games: The number of games.
+//   - Value: The sum of the PriceCharting values of the games.
+type GroupStats struct {
+	Name  string  `json:"name"`
+	Games int     `json:"games"`
+	Value float64 `json:"value"`
+}
+
+// CompletenessStats sort the games by how complete they are. A game is
+// complete in box with its game, box and manual, loose with only the game,
+// and partial otherwise, e.g. boxed without manual or a box without the game.
+//
+// Fields:
+//   - CompleteInBox: The number of games with their game, box and manual.
+//   - Loose: The number of games with only the game.
+//   - Partial: The number of other games.
+//   - CompleteInBoxRatio: The share of games complete in box, from 0 to 1.
+//   - LooseRatio: The share of loose games, from 0 to 1.
+type CompletenessStats struct {
+	CompleteInBox      int     `json:"complete_in_box"`
+	Loose              int     `json:"loose"`
+	Partial            int     `json:"partial"`
+	CompleteInBoxRatio float64 `json:"complete_in_box_ratio"`
+	LooseRatio         float64 `json:"loose_ratio"`
+}
+
+// AcquisitionStats are the games acquired in a month.
+//
+// Fields:
+//   - Month: The month, formatted as 2006-01.
+//   - Games: The number of games acquired in the month.
+type AcquisitionStats struct {
+	Month string `json:"month"`
+	Games int    `json:"games"`
+}
+
+// EnrichmentStats count the games supplemented with IGDB data.
+//
+// Fields:
+//   - IGDBIDs: The number of games matched to an IGDB game.
+//   - Covers: The number of games with an IGDB cover.
+//   - Summaries: The number of games with an IGDB summary.
+type EnrichmentStats struct {
+	IGDBIDs   int `json:"igdb_ids"`
+	Covers    int `json:"covers"`
+	Summaries int `json:"summaries"`
+}
+
+// ComputeStats aggregates the games of a collection.
+//
+// Parameters:
+//   - games: The games to aggregate.
+//
+// Returns:
+//   - The Stats of the games.
+func ComputeStats(games []Game) Stats {
+	stats := Stats{Games: len(games)}
+
+	platforms := groupCounter{}
+	regions := groupCounter{}
+	formats := groupCounter{}
+	genres := groupCounter{}
+	acquisitions := map[string]int{}
+
+	for _, game := range games {
+		stats.Value += game.PricechartingValue
+
+		platforms.add(string(game.Platform), game)
+		regions.add(game.Region, game)
+		formats.add(game.Format, game)
+		if len(game.Genres) == 0 {
+			genres.add("", game)
+		}
+		for _, genre := range game.Genres {
+			genres.add(genre, game)
+		}
+
+		completeness := game.Completeness
+		switch {
+		case completeness.HasGame && completeness.HasBox && completeness.HasManual:
+			stats.Completeness.CompleteInBox++
+		case completeness.HasGame && !completeness.HasBox && !completeness.HasManual:
+			stats.Completeness.Loose++
+		default:
+			stats.Completeness.Partial++
+		}
+
+		if game.DateAcquired.IsZero() {
+			stats.AcquisitionUnknown++
+		} else {
+			acquisitions[game.DateAcquired.Format("2006-01")]++
+		}
+
+		if game.IGDB_ID != 0 {
+			stats.Enrichment.IGDBIDs++
+		}
+		if game.Cover.URL != "" {
+			stats.Enrichment.Covers++
+		}
+		if game.Summary != "" {
+			stats.Enrichment.Summaries++
+		}
+	}
+
+	stats.Value = roundCents(stats.Value)
+
+	if stats.Games > 0 {
+		stats.Completeness.CompleteInBoxRatio = float64(stats.Completeness.CompleteInBox) / float64(stats.Games)
+		stats.Completeness.LooseRatio = float64(stats.Completeness.Loose) / float64(stats.Games)
+	}
+
+	stats.Platforms = platforms.sorted()
+	stats.Regions = regions.sorted()
+	stats.Formats = formats.sorted()
+	stats.Genres = genres.sorted()
+
+	stats.Acquisitions = []AcquisitionStats{}
+	for month, count := range acquisitions {
+		stats.Acquisitions = append(stats.Acquisitions, AcquisitionStats{Month: month, Games: count})
+	}
+	sort.Slice(stats.Acquisitions, func(i, j int) bool {
+		return stats.Acquisitions[i].Month < stats.Acquisitions[j].Month
+	})
+
+	return stats
+}
+
+// groupCounter aggregates games by a value, keyed by the value.
+type groupCounter map[string]*GroupStats
+
+func (c groupCounter) add(name string, game Game) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = unknownGroup
+	}
+
+	group, ok := c[name]
+	if !ok {
+		group = &GroupStats{Name: name}
+		c[name] = group
+	}
+
+	group.Games++
+	group.Value += game.PricechartingValue
+}
+
+// sorted returns the groups with the most games first, then by name.
+func (c groupCounter) sorted() []GroupStats {
+	groups := make([]GroupStats, 0, len(c))
+	for _, group := range c {
+		group.Value = roundCents(group.Value)
+		groups = append(groups, *group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Games != groups[j].Games {
+			return groups[i].Games > groups[j].Games
+		}
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups
+}
+
+// roundCents rounds a sum of amounts to cents, dropping floating point noise.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
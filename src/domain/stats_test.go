@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComputeStats(t *testing.T) {
+	acquired := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	games := []Game{
+		{
+			Platform: NES, Region: "USA", Format: "Cartridge", Genres: []string{"Platform", "Action"},
+			Completeness: Completeness{HasGame: true, HasBox: true, HasManual: true}, PricechartingValue: 30.1,
+			DateAcquired: acquired(2024, time.May, 3), IGDB_ID: 1068, Cover: Cover{URL: "//images.igdb.com/co1j8f.jpg"}, Summary: "A summary.",
+		},
+		{
+			Platform: NES, Region: "USA", Format: "Cartridge", Genres: []string{"Action"},
+			Completeness: Completeness{HasGame: true}, PricechartingValue: 10.2,
+			DateAcquired: acquired(2024, time.May, 20), IGDB_ID: 1337,
+		},
+		{
+			Platform: Saturn, Format: "CD", Completeness: Completeness{HasGame: true, HasBox: true}, PricechartingValue: 45,
+			DateAcquired: acquired(2023, time.December, 24),
+		},
+		{Platform: Saturn, Completeness: Completeness{HasBox: true}},
+	}
+
+	stats := ComputeStats(games)
+
+	if stats.Games != 4 || stats.Value != 85.3 {
+		t.Errorf("Expected 4 games valued at 85.3, but got %d valued at %v", stats.Games, stats.Value)
+	}
+
+	expectedPlatforms := []GroupStats{{Name: "NES", Games: 2, Value: 40.3}, {Name: "Saturn", Games: 2, Value: 45}}
+	if !reflect.DeepEqual(stats.Platforms, expectedPlatforms) {
+		t.Errorf("Expected platforms %v, but got %v", expectedPlatforms, stats.Platforms)
+	}
+
+	expectedRegions := []GroupStats{{Name: "USA", Games: 2, Value: 40.3}, {Name: "Unknown", Games: 2, Value: 45}}
+	if !reflect.DeepEqual(stats.Regions, expectedRegions) {
+		t.Errorf("Expected regions %v, but got %v", expectedRegions, stats.Regions)
+	}
+
+	expectedGenres := []GroupStats{{Name: "Action", Games: 2, Value: 40.3}, {Name: "Unknown", Games: 2, Value: 45}, {Name: "Platform", Games: 1, Value: 30.1}}
+	if !reflect.DeepEqual(stats.Genres, expectedGenres) {
+		t.Errorf("Expected genres %v, but got %v", expectedGenres, stats.Genres)
+	}
+
+	expectedCompleteness := CompletenessStats{CompleteInBox: 1, Loose: 1, Partial: 2, CompleteInBoxRatio: 0.25, LooseRatio: 0.25}
+	if stats.Completeness != expectedCompleteness {
+		t.Errorf("Expected completeness %v, but got %v", expectedCompleteness, stats.Completeness)
+	}
+
+	expectedAcquisitions := []AcquisitionStats{{Month: "2023-12", Games: 1}, {Month: "2024-05", Games: 2}}
+	if !reflect.DeepEqual(stats.Acquisitions, expectedAcquisitions) || stats.AcquisitionUnknown != 1 {
+		t.Errorf("Expected acquisitions %v and 1 unknown, but got %v and %d", expectedAcquisitions, stats.Acquisitions, stats.AcquisitionUnknown)
+	}
+
+	expectedEnrichment := EnrichmentStats{IGDBIDs: 2, Covers: 1, Summaries: 1}
+	if stats.Enrichment != expectedEnrichment {
+		t.Errorf("Expected enrichment %v, but got %v", expectedEnrichment, stats.Enrichment)
+	}
+
+	empty := ComputeStats(nil)
+	if empty.Games != 0 || empty.Completeness.CompleteInBoxRatio != 0 || len(empty.Platforms) != 0 {
+		t.Errorf("Expected empty stats for no games, but got %v", empty)
+	}
+}